@@ -0,0 +1,9 @@
+# Copyright (c) HashiCorp, Inc.
+
+data "smc_folder" "europe" {
+  path = "Europe"
+}
+
+data "smc_firewalls" "europe" {
+  folder = data.smc_folder.europe.uuid
+}
@@ -0,0 +1,5 @@
+# Copyright (c) HashiCorp, Inc.
+
+data "smc_folder" "paris" {
+  path = "Europe/Paris"
+}
@@ -0,0 +1,26 @@
+# Copyright (c) HashiCorp, Inc.
+
+data "smc_folder" "europe" {
+  path = "Europe"
+}
+
+data "smc_firewalls" "europe" {
+  folder = data.smc_folder.europe.uuid
+}
+
+resource "smc_deployment" "europe" {
+  targets = data.smc_firewalls.europe.firewalls[*].uuid
+  comment = "Deployed by Terraform"
+  timeout = "15m"
+
+  # Deploy again when the folder changes.
+  triggers = {
+    folder = smc_folder.paris.description
+  }
+}
+
+resource "smc_folder" "paris" {
+  name          = "Paris"
+  parent_folder = data.smc_folder.europe.uuid
+  description   = "Paris offices"
+}
@@ -0,0 +1,9 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Folder can be imported by specifying either the UUID of the folder or its path.
+terraform import smc_folder.paris 0cb4e4d4-6f68-4bd0-9d2c-6c1e7e4e2f3a
+terraform import smc_folder.paris Europe/Paris
+
+# An ID without slash is taken as a UUID, the path of a top-level folder must
+# start with a slash.
+terraform import smc_folder.europe /Europe
@@ -0,0 +1,12 @@
+# Copyright (c) HashiCorp, Inc.
+
+resource "smc_folder" "europe" {
+  name        = "Europe"
+  description = "European sites"
+}
+
+resource "smc_folder" "paris" {
+  name          = "Paris"
+  parent_folder = smc_folder.europe.uuid
+  description   = "Paris offices"
+}
@@ -0,0 +1,27 @@
+# Copyright (c) HashiCorp, Inc.
+
+resource "smc_vpn_topology_mesh" "branches" {
+  name               = "branches"
+  encryption_profile = "encryption-profile-uuid"
+  psk                = var.vpn_psk
+
+  peers = [
+    {
+      firewall           = "firewall-paris-uuid"
+      protected_networks = ["network-paris-uuid"]
+    },
+    {
+      firewall           = "firewall-lyon-uuid"
+      protected_networks = ["network-lyon-uuid"]
+    },
+    {
+      firewall           = "firewall-lille-uuid"
+      protected_networks = ["network-lille-uuid"]
+    },
+  ]
+}
+
+variable "vpn_psk" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,4 @@
+# Copyright (c) HashiCorp, Inc.
+
+# VPN topology can be imported by specifying the UUID of the topology.
+terraform import smc_vpn_topology_star.branches 5f8c0b7a-2d4e-4a4f-9b3c-1e2d3f4a5b6c
@@ -0,0 +1,20 @@
+# Copyright (c) HashiCorp, Inc.
+
+resource "smc_vpn_topology_star" "branches" {
+  name                    = "branches"
+  encryption_profile      = "encryption-profile-uuid"
+  certificate_authorities = ["certificate-authority-uuid"]
+  center                  = "firewall-paris-uuid"
+  responder_only          = true
+
+  peers = [
+    {
+      firewall           = "firewall-paris-uuid"
+      protected_networks = ["network-paris-uuid"]
+    },
+    {
+      firewall           = "firewall-lyon-uuid"
+      protected_networks = ["network-lyon-uuid"]
+    },
+  ]
+}
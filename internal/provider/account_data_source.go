@@ -23,7 +23,7 @@ func NewAccountDataSource() datasource.DataSource {
 
 // AccountDataSource defines the data source implementation.
 type AccountDataSource struct {
-	client *smc.ClientWithResponses
+	providerData *SMCProviderData
 }
 
 // AccountDataSourceModel describes the data source data model.
@@ -104,17 +104,17 @@ func (d *AccountDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*smc.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*SMCProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *smc.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func readAccountDataSourceModel(data *AccountDataSourceModel, item *smc.DefinitionsAccountsAccountPropertiesWithoutPassword) {
@@ -160,7 +160,7 @@ func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	respAPI, err := d.client.GetApiAccountsUuidWithResponse(ctx, data.Identifier.ValueString())
+	respAPI, err := d.providerData.Client.GetApiAccountsUuidWithResponse(ctx, data.Identifier.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading SMC Account",
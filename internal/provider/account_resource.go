@@ -3,20 +3,22 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,6 +29,7 @@ import (
 var _ resource.Resource = &AccountResource{}
 var _ resource.ResourceWithConfigure = &AccountResource{}
 var _ resource.ResourceWithImportState = &AccountResource{}
+var _ resource.ResourceWithUpgradeState = &AccountResource{}
 
 func NewAccountResource() resource.Resource {
 	return &AccountResource{}
@@ -34,23 +37,23 @@ func NewAccountResource() resource.Resource {
 
 // AccountResource defines the resource implementation.
 type AccountResource struct {
-	client *smc.ClientWithResponses
+	providerData *SMCProviderData
 }
 
 // AccountResourceModel describes the resource data model.
 type AccountResourceModel struct {
-	Description types.String `tfsdk:"description" json:"description"`
-	DN          types.String `tfsdk:"dn" json:"dn"`
-	Email       types.String `tfsdk:"email" json:"email"`
-	Folders     types.List   `tfsdk:"folders" json:"folders"`
-	Identifier  types.String `tfsdk:"identifier" json:"identifier"`
-	Kind        types.String `tfsdk:"kind" json:"kind"`
-	LastUpdated types.String `tfsdk:"last_updated" json:"last_updated"`
-	LocalAuth   types.Bool   `tfsdk:"local_auth" json:"local_auth"`
-	Name        types.String `tfsdk:"name" json:"name"`
-	Password    types.String `tfsdk:"password" json:"password"`
-	Permissions types.List   `tfsdk:"permissions" json:"permissions"`
-	UUID        types.String `tfsdk:"uuid" json:"uuid"`
+	Description types.String `tfsdk:"description"`
+	DN          types.String `tfsdk:"dn"`
+	Email       types.String `tfsdk:"email"`
+	Folders     types.Set    `tfsdk:"folders"`
+	Identifier  types.String `tfsdk:"identifier"`
+	Kind        types.String `tfsdk:"kind"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	LocalAuth   types.Bool   `tfsdk:"local_auth"`
+	Name        types.String `tfsdk:"name"`
+	Password    types.String `tfsdk:"password"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	UUID        types.String `tfsdk:"uuid"`
 }
 
 func (r *AccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,6 +64,8 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Manage the account resource.",
+		// Version 1 changed folders and permissions from lists to sets.
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"description": schema.StringAttribute{
@@ -75,7 +80,7 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Account's email",
 				Optional:            true,
 			},
-			"folders": schema.ListAttribute{
+			"folders": schema.SetAttribute{
 				MarkdownDescription: "Array of folder rights",
 				Optional:            true,
 				ElementType:         types.StringType,
@@ -85,8 +90,13 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 			},
 			"kind": schema.StringAttribute{
-				MarkdownDescription: "Type of account (user or group)",
+				MarkdownDescription: "Type of account (user or group), read from the SMC when not set, changing it replaces the account",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 				Validators: []validator.String{
 					stringvalidator.OneOf(
 						"user",
@@ -95,11 +105,16 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"last_updated": schema.StringAttribute{
-				Computed: true,
+				MarkdownDescription: "Time of the last change of the account, by Terraform or outside of it",
+				Computed:            true,
 			},
 			"local_auth": schema.BoolAttribute{
-				MarkdownDescription: "does the user can use the local authentication",
+				MarkdownDescription: "does the user can use the local authentication, read from the SMC when not set",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "the user's name",
@@ -116,12 +131,12 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 					),
 				},
 			},
-			"permissions": schema.ListAttribute{
+			"permissions": schema.SetAttribute{
 				MarkdownDescription: "Array of access rights",
 				Optional:            true,
 				ElementType:         types.StringType,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
 						stringvalidator.OneOf(
 							"smc",
 							"sns",
@@ -135,6 +150,109 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"uuid": schema.StringAttribute{
 				MarkdownDescription: "Account uuid",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// AccountResourceModelV0 describes the resource data model of the schema
+// version 0, in which folders and permissions are lists.
+type AccountResourceModelV0 struct {
+	Description types.String `tfsdk:"description"`
+	DN          types.String `tfsdk:"dn"`
+	Email       types.String `tfsdk:"email"`
+	Folders     types.List   `tfsdk:"folders"`
+	Identifier  types.String `tfsdk:"identifier"`
+	Kind        types.String `tfsdk:"kind"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	LocalAuth   types.Bool   `tfsdk:"local_auth"`
+	Name        types.String `tfsdk:"name"`
+	Password    types.String `tfsdk:"password"`
+	Permissions types.List   `tfsdk:"permissions"`
+	UUID        types.String `tfsdk:"uuid"`
+}
+
+// listToSet converts a list of strings of the schema version 0 to a set,
+// dropping the duplicates.
+func listToSet(ctx context.Context, value types.List) (types.Set, diag.Diagnostics) {
+	if value.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+
+	if value.IsUnknown() {
+		return types.SetUnknown(types.StringType), nil
+	}
+
+	var elements []string
+	diags := value.ElementsAs(ctx, &elements, false)
+
+	return stringSetValue(elements), diags
+}
+
+func (r *AccountResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// The schema version 0 only differs by the type of folders and
+	// permissions.
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = make(map[string]schema.Attribute, len(schemaResp.Schema.Attributes))
+
+	for name, attribute := range schemaResp.Schema.Attributes {
+		priorSchema.Attributes[name] = attribute
+	}
+
+	priorSchema.Attributes["folders"] = schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	priorSchema.Attributes["permissions"] = schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AccountResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				folders, diags := listToSet(ctx, prior.Folders)
+				resp.Diagnostics.Append(diags...)
+
+				permissions, diags := listToSet(ctx, prior.Permissions)
+				resp.Diagnostics.Append(diags...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := AccountResourceModel{
+					Description: prior.Description,
+					DN:          prior.DN,
+					Email:       prior.Email,
+					Folders:     folders,
+					Identifier:  prior.Identifier,
+					Kind:        prior.Kind,
+					LastUpdated: prior.LastUpdated,
+					LocalAuth:   prior.LocalAuth,
+					Name:        prior.Name,
+					Password:    prior.Password,
+					Permissions: permissions,
+					UUID:        prior.UUID,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			},
 		},
 	}
@@ -146,53 +264,225 @@ func (r *AccountResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*smc.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*SMCProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *smc.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
-func readAccountResourceModel(data *AccountResourceModel, item *smc.DefinitionsAccountsAccountPropertiesWithoutPassword) {
-	data.Description = types.StringPointerValue(item.Description)
-	data.DN = types.StringPointerValue(item.Dn)
-	data.Email = types.StringPointerValue(item.Email)
-
-	if item.Folders != nil {
-		folderAttrs := make([]attr.Value, len(*item.Folders))
-		for idx, folder := range *item.Folders {
-			folderAttrs[idx] = types.StringValue(folder)
+// knownStringPointer returns a pointer to the value, or nil when it is null
+// or unknown so that it is omitted from the API requests.
+func knownStringPointer(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	return value.ValueStringPointer()
+}
+
+// knownBoolPointer returns a pointer to the value, or nil when it is null or
+// unknown so that it is omitted from the API requests.
+func knownBoolPointer(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	return value.ValueBoolPointer()
+}
+
+// knownStrings returns the string elements of the value, or nil when it is
+// null or unknown so that it is omitted from the API requests.
+func knownStrings(ctx context.Context, value types.Set) (*[]string, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var elements []string
+	diags := value.ElementsAs(ctx, &elements, false)
+
+	return &elements, diags
+}
+
+// createRequest returns the API request creating the account, holding only
+// the writable attributes set in the configuration.
+func (data *AccountResourceModel) createRequest(ctx context.Context) (smc.DefinitionsAccountsAccountCreateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	body := smc.DefinitionsAccountsAccountCreateRequest{
+		Description: knownStringPointer(data.Description),
+		Dn:          knownStringPointer(data.DN),
+		Email:       knownStringPointer(data.Email),
+		Identifier:  knownStringPointer(data.Identifier),
+		Kind:        knownStringPointer(data.Kind),
+		LocalAuth:   knownBoolPointer(data.LocalAuth),
+		Name:        knownStringPointer(data.Name),
+		Password:    knownStringPointer(data.Password),
+	}
+
+	folders, d := knownStrings(ctx, data.Folders)
+	diags.Append(d...)
+	body.Folders = folders
+
+	permissions, d := knownStrings(ctx, data.Permissions)
+	diags.Append(d...)
+
+	if permissions != nil {
+		bodyPermissions := make([]smc.DefinitionsAccountsAccountCreateRequestPermissions, len(*permissions))
+		for idx, permission := range *permissions {
+			bodyPermissions[idx] = smc.DefinitionsAccountsAccountCreateRequestPermissions(permission)
 		}
 
-		listValue, _ := types.ListValue(types.StringType, folderAttrs)
-		data.Folders = listValue
+		body.Permissions = &bodyPermissions
+	}
+
+	return body, diags
+}
+
+// clearedStringPointer returns a pointer to the value, or to an empty string
+// when it was removed from the configuration but is set in the prior state so
+// that the SMC clears it.
+func clearedStringPointer(value, prior types.String) *string {
+	if value.IsNull() && !prior.IsNull() {
+		cleared := ""
+		return &cleared
+	}
+
+	return knownStringPointer(value)
+}
+
+// updateRequest returns the API request updating the account with the given
+// UUID, holding the writable attributes set in the configuration, and the
+// empty values of the ones removed from it since the prior state.
+func (data *AccountResourceModel) updateRequest(ctx context.Context, uuid string, state *AccountResourceModel) (smc.DefinitionsAccountsAccountUpdateRequest, diag.Diagnostics) {
+	createBody, diags := data.createRequest(ctx)
+
+	body := smc.DefinitionsAccountsAccountUpdateRequest{
+		Description: clearedStringPointer(data.Description, state.Description),
+		Dn:          clearedStringPointer(data.DN, state.DN),
+		Email:       clearedStringPointer(data.Email, state.Email),
+		Folders:     createBody.Folders,
+		Identifier:  clearedStringPointer(data.Identifier, state.Identifier),
+		Kind:        createBody.Kind,
+		LocalAuth:   createBody.LocalAuth,
+		Name:        clearedStringPointer(data.Name, state.Name),
+		Password:    createBody.Password,
+		Uuid:        uuid,
 	}
 
-	data.Identifier = types.StringPointerValue(item.Identifier)
+	if data.Folders.IsNull() && !state.Folders.IsNull() {
+		body.Folders = &[]string{}
+	}
+
+	if data.Permissions.IsNull() && !state.Permissions.IsNull() {
+		body.Permissions = &[]smc.DefinitionsAccountsAccountUpdateRequestPermissions{}
+	}
+
+	if createBody.Permissions != nil {
+		permissions := make([]smc.DefinitionsAccountsAccountUpdateRequestPermissions, len(*createBody.Permissions))
+		for idx, permission := range *createBody.Permissions {
+			permissions[idx] = smc.DefinitionsAccountsAccountUpdateRequestPermissions(permission)
+		}
+
+		body.Permissions = &permissions
+	}
+
+	return body, diags
+}
+
+// stringSetValue returns a set of the given strings, without the duplicates.
+func stringSetValue(elements []string) types.Set {
+	seen := make(map[string]bool, len(elements))
+	elementAttrs := make([]attr.Value, 0, len(elements))
+
+	for _, element := range elements {
+		if seen[element] {
+			continue
+		}
+
+		seen[element] = true
+		elementAttrs = append(elementAttrs, types.StringValue(element))
+	}
+
+	setValue, _ := types.SetValue(types.StringType, elementAttrs)
+
+	return setValue
+}
+
+func readAccountResourceModel(data *AccountResourceModel, item *smc.DefinitionsAccountsAccountPropertiesWithoutPassword) {
+	data.Description = stringPointerOrPrior(item.Description, data.Description)
+	data.DN = stringPointerOrPrior(item.Dn, data.DN)
+	data.Email = stringPointerOrPrior(item.Email, data.Email)
+
+	// The SMC returns empty arrays for the cleared folders and permissions,
+	// they stay null when they are not set.
+	if item.Folders != nil && (len(*item.Folders) > 0 || !data.Folders.IsNull()) {
+		data.Folders = stringSetValue(*item.Folders)
+	}
+
+	data.Identifier = stringPointerOrPrior(item.Identifier, data.Identifier)
 	data.Kind = types.StringPointerValue(item.Kind)
 	data.LocalAuth = types.BoolPointerValue(item.LocalAuth)
-	data.Name = types.StringPointerValue(item.Name)
+	data.Name = stringPointerOrPrior(item.Name, data.Name)
 
-	if item.Permissions != nil {
-		permissionAttrs := make([]attr.Value, len(*item.Permissions))
+	if item.Permissions != nil && (len(*item.Permissions) > 0 || !data.Permissions.IsNull()) {
+		permissions := make([]string, len(*item.Permissions))
 		for idx, permission := range *item.Permissions {
-			permissionAttrs[idx] = types.StringValue(string(permission))
+			permissions[idx] = string(permission)
 		}
 
-		listValue, _ := types.ListValue(types.StringType, permissionAttrs)
-		data.Permissions = listValue
+		data.Permissions = stringSetValue(permissions)
 	}
 
 	data.UUID = types.StringValue(item.Uuid)
+}
+
+// stringPointerOrNull returns a null string for nil or empty values, the SMC
+// returning empty strings for the cleared fields.
+func stringPointerOrNull(value *string) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+
+	return stringOrNull(*value)
+}
 
-	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+// stringPointerOrPrior returns the value read from the SMC as
+// stringPointerOrNull does, but keeps the empty string of the prior value so
+// that the attributes set to "" in the configuration stay consistent.
+func stringPointerOrPrior(value *string, prior types.String) types.String {
+	if (value == nil || *value == "") && prior.Equal(types.StringValue("")) {
+		return prior
+	}
+
+	return stringPointerOrNull(value)
+}
+
+// sameAccount reports whether the attributes read from the SMC are the same in
+// both models.
+func (data *AccountResourceModel) sameAccount(other *AccountResourceModel) bool {
+	return data.Description.Equal(other.Description) &&
+		data.DN.Equal(other.DN) &&
+		data.Email.Equal(other.Email) &&
+		data.Folders.Equal(other.Folders) &&
+		data.Identifier.Equal(other.Identifier) &&
+		data.Kind.Equal(other.Kind) &&
+		data.LocalAuth.Equal(other.LocalAuth) &&
+		data.Name.Equal(other.Name) &&
+		data.Permissions.Equal(other.Permissions) &&
+		data.UUID.Equal(other.UUID)
+}
+
+// lastUpdatedNow returns the last_updated value of an account changed now.
+func lastUpdatedNow() types.String {
+	return types.StringValue(time.Now().Format(time.RFC850))
 }
 
 func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -205,16 +495,14 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	body, err := json.Marshal(data)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting the JSON encoding of the SMC Account data",
-			"Could not get the JSON encoding of the SMC Account data: "+err.Error(),
-		)
+	body, diags := data.createRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	respAPI, err := r.client.PostApiAccountsWithBodyWithResponse(ctx, "application/json", bytes.NewBuffer(body))
+	respAPI, err := r.providerData.Client.PostApiAccountsWithResponse(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating the SMC Account",
@@ -240,6 +528,7 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	readAccountResourceModel(&data, respAPI.JSON201.Result)
+	data.LastUpdated = lastUpdatedNow()
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "Created an account", map[string]interface{}{"uuid": data.UUID})
@@ -258,7 +547,7 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	respAPI, err := r.client.GetApiAccountsUuidWithResponse(ctx, data.UUID.ValueString())
+	respAPI, err := r.providerData.Client.GetApiAccountsUuidWithResponse(ctx, data.UUID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading the SMC Account",
@@ -267,6 +556,14 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// The account was deleted outside of Terraform, it is planned for
+	// creation again.
+	if respAPI.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "Account not found, removing it from the state", map[string]interface{}{"uuid": data.UUID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if respAPI.StatusCode() != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"HTTP Error Reading the SMC Account",
@@ -283,8 +580,15 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	prior := data
 	readAccountResourceModel(&data, respAPI.JSON200)
 
+	// last_updated only changes when the account was changed outside of
+	// Terraform, or when it is imported.
+	if data.LastUpdated.IsNull() || !data.sameAccount(&prior) {
+		data.LastUpdated = lastUpdatedNow()
+	}
+
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "Read an account", map[string]interface{}{"uuid": data.UUID})
 
@@ -293,29 +597,28 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AccountResourceModel
+	var data, state AccountResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	body, err := json.Marshal(data)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting the JSON encoding of the SMC Account data",
-			"Could not get the JSON encoding of the SMC Account data: "+err.Error(),
-		)
+	body, diags := data.updateRequest(ctx, state.UUID.ValueString(), &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	respAPI, err := r.client.PutApiAccountsUuidWithBodyWithResponse(ctx, data.UUID.String(), "application/json", bytes.NewBuffer(body))
+	respAPI, err := r.providerData.Client.PutApiAccountsUuidWithResponse(ctx, state.UUID.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating the SMC Account",
-			"Could not update the SMC account UUID "+data.UUID.String()+": "+err.Error(),
+			"Could not update the SMC account UUID "+state.UUID.ValueString()+": "+err.Error(),
 		)
 		return
 	}
@@ -337,6 +640,7 @@ func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	readAccountResourceModel(&data, respAPI.JSON200.Result)
+	data.LastUpdated = lastUpdatedNow()
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "Updated an account", map[string]interface{}{"uuid": data.UUID})
@@ -355,15 +659,21 @@ func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	respAPI, err := r.client.DeleteApiAccountsUuidWithResponse(ctx, data.UUID.String())
+	respAPI, err := r.providerData.Client.DeleteApiAccountsUuidWithResponse(ctx, data.UUID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting the SMC Account",
-			"Could not delete the SMC account UUID "+data.UUID.String()+": "+err.Error(),
+			"Could not delete the SMC account UUID "+data.UUID.ValueString()+": "+err.Error(),
 		)
 		return
 	}
 
+	// The account was already deleted outside of Terraform.
+	if respAPI.StatusCode() == http.StatusNotFound {
+		tflog.Trace(ctx, "Account already deleted", map[string]interface{}{"uuid": data.UUID})
+		return
+	}
+
 	if respAPI.StatusCode() != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"HTTP Error Deleting the SMC Account",
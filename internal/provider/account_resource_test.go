@@ -3,12 +3,25 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trois-six/smc"
 )
 
 var step = 0
@@ -97,13 +110,13 @@ func TestAccAccountResource(t *testing.T) {
 					resource.TestCheckResourceAttr("smc_account.jdoe", "dn", "CN=bob,DC=company,DC=world"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "email", "user@email.com"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "folders.#", "1"),
-					resource.TestCheckResourceAttr("smc_account.jdoe", "folders.0", "folder-uuid"),
+					resource.TestCheckTypeSetElemAttr("smc_account.jdoe", "folders.*", "folder-uuid"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "identifier", "jdoe"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "kind", "user"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "local_auth", "true"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "name", "Some Account name"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "permissions.#", "1"),
-					resource.TestCheckResourceAttr("smc_account.jdoe", "permissions.0", "smc"),
+					resource.TestCheckTypeSetElemAttr("smc_account.jdoe", "permissions.*", "smc"),
 					resource.TestCheckResourceAttr("smc_account.jdoe", "uuid", "75532250-c878-42f1-8871-bafa68e944d4"),
 				),
 			},
@@ -141,3 +154,519 @@ resource "smc_account" "jdoe" {
 }
 `, description)
 }
+
+// testAccAccountServer returns a SMC holding the jdoe account until deleted
+// is set, answering 404 for it afterwards.
+func testAccAccountServer(t *testing.T, deleted *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	const account = `{
+  "uuid": "75532250-c878-42f1-8871-bafa68e944d4",
+  "dn": "CN=bob,DC=company,DC=world",
+  "email": "user@email.com",
+  "identifier": "jdoe",
+  "kind": "user",
+  "localAuth": true,
+  "name": "Some Account name"
+}`
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/accounts":
+			deleted.Store(false)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"result": ` + account + `, "success": true}`))
+		case r.URL.Path != "/api/accounts/75532250-c878-42f1-8871-bafa68e944d4":
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		case deleted.Load():
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": "ENOTFOUND", "message": "account not found"}]}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(account))
+		case r.Method == http.MethodDelete:
+			deleted.Store(true)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"result": ` + account + `, "success": true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	return testServer
+}
+
+func TestAccAccountResourceDeletedOutsideTerraform(t *testing.T) {
+	var deleted atomic.Bool
+
+	testServer := testAccAccountServer(t, &deleted)
+
+	config := fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_account" "jdoe" {
+  dn         = "CN=bob,DC=company,DC=world"
+  email      = "user@email.com"
+  identifier = "jdoe"
+  kind       = "user"
+  local_auth = true
+  name       = "Some Account name"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("smc_account.jdoe", "uuid", "75532250-c878-42f1-8871-bafa68e944d4"),
+			},
+			// The account is deleted in the SMC console, the plan creates
+			// it again instead of failing.
+			{
+				PreConfig:          func() { deleted.Store(true) },
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The destroy of the account already deleted succeeds.
+		},
+	})
+}
+
+func TestAccAccountResourceKindUnset(t *testing.T) {
+	var deleted atomic.Bool
+
+	testServer := testAccAccountServer(t, &deleted)
+
+	config := fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_account" "jdoe" {
+  dn         = "CN=bob,DC=company,DC=world"
+  email      = "user@email.com"
+  identifier = "jdoe"
+  kind       = "user"
+  local_auth = true
+  name       = "Some Account name"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("smc_account.jdoe", "kind", "user"),
+			},
+			// Removing kind from the configuration keeps the one read from
+			// the SMC instead of replacing the account.
+			{
+				Config:   strings.Replace(config, "  kind       = \"user\"\n", "", 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAccountResourceLocalAuthUnset(t *testing.T) {
+	var deleted atomic.Bool
+
+	testServer := testAccAccountServer(t, &deleted)
+
+	config := fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_account" "jdoe" {
+  dn         = "CN=bob,DC=company,DC=world"
+  email      = "user@email.com"
+  identifier = "jdoe"
+  kind       = "user"
+  local_auth = true
+  name       = "Some Account name"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("smc_account.jdoe", "local_auth", "true"),
+			},
+			// Removing local_auth from the configuration keeps the one read
+			// from the SMC instead of planning an inconsistent null value.
+			{
+				Config:   strings.Replace(config, "  local_auth = true\n", "", 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccountResourceModel(t *testing.T) AccountResourceModel {
+	t.Helper()
+
+	folders, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"folder-uuid"})
+	require.False(t, diags.HasError())
+
+	permissions, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"smc", "api"})
+	require.False(t, diags.HasError())
+
+	return AccountResourceModel{
+		Description: types.StringValue("some user description"),
+		DN:          types.StringValue("CN=bob,DC=company,DC=world"),
+		Email:       types.StringValue("user@email.com"),
+		Folders:     folders,
+		Identifier:  types.StringValue("jdoe"),
+		Kind:        types.StringValue("user"),
+		LastUpdated: types.StringValue("Monday, 02-Jan-06 15:04:05 MST"),
+		LocalAuth:   types.BoolValue(true),
+		Name:        types.StringValue("Some Account name"),
+		Password:    types.StringValue("$2a$10$HM7zy3pUuoyKwnaFk4A4W.9gLQZ3BGWeJqwdlPiOJN6TayLbSQ1Na"),
+		Permissions: permissions,
+		UUID:        types.StringValue("75532250-c878-42f1-8871-bafa68e944d4"),
+	}
+}
+
+func TestAccountResourceModelCreateRequest(t *testing.T) {
+	data := testAccountResourceModel(t)
+
+	body, diags := data.createRequest(context.Background())
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, "some user description", *body.Description)
+	assert.Equal(t, "CN=bob,DC=company,DC=world", *body.Dn)
+	assert.Equal(t, "user@email.com", *body.Email)
+	assert.Equal(t, []string{"folder-uuid"}, *body.Folders)
+	assert.Equal(t, "jdoe", *body.Identifier)
+	assert.Equal(t, "user", *body.Kind)
+	assert.True(t, *body.LocalAuth)
+	assert.Equal(t, "Some Account name", *body.Name)
+	assert.Equal(t, "$2a$10$HM7zy3pUuoyKwnaFk4A4W.9gLQZ3BGWeJqwdlPiOJN6TayLbSQ1Na", *body.Password)
+	assert.Equal(t, []smc.DefinitionsAccountsAccountCreateRequestPermissions{"smc", "api"}, *body.Permissions)
+
+	// Only the writable attributes are sent, with the API names.
+	encoded, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "description": "some user description",
+  "dn": "CN=bob,DC=company,DC=world",
+  "email": "user@email.com",
+  "folders": ["folder-uuid"],
+  "identifier": "jdoe",
+  "kind": "user",
+  "localAuth": true,
+  "name": "Some Account name",
+  "password": "$2a$10$HM7zy3pUuoyKwnaFk4A4W.9gLQZ3BGWeJqwdlPiOJN6TayLbSQ1Na",
+  "permissions": ["smc", "api"]
+}`, string(encoded))
+}
+
+func TestAccountResourceModelCreateRequestOmitsNullAndUnknown(t *testing.T) {
+	for name, data := range map[string]AccountResourceModel{
+		"null": {
+			Description: types.StringNull(),
+			DN:          types.StringNull(),
+			Email:       types.StringNull(),
+			Folders:     types.SetNull(types.StringType),
+			Identifier:  types.StringNull(),
+			Kind:        types.StringNull(),
+			LastUpdated: types.StringNull(),
+			LocalAuth:   types.BoolNull(),
+			Name:        types.StringNull(),
+			Password:    types.StringNull(),
+			Permissions: types.SetNull(types.StringType),
+			UUID:        types.StringNull(),
+		},
+		"unknown": {
+			Description: types.StringUnknown(),
+			DN:          types.StringUnknown(),
+			Email:       types.StringUnknown(),
+			Folders:     types.SetUnknown(types.StringType),
+			Identifier:  types.StringUnknown(),
+			Kind:        types.StringUnknown(),
+			LastUpdated: types.StringUnknown(),
+			LocalAuth:   types.BoolUnknown(),
+			Name:        types.StringUnknown(),
+			Password:    types.StringUnknown(),
+			Permissions: types.SetUnknown(types.StringType),
+			UUID:        types.StringUnknown(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			body, diags := data.createRequest(context.Background())
+			require.False(t, diags.HasError())
+
+			encoded, err := json.Marshal(body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{}`, string(encoded))
+		})
+	}
+}
+
+func TestAccountResourceModelUpdateRequest(t *testing.T) {
+	data := testAccountResourceModel(t)
+	state := data
+
+	body, diags := data.updateRequest(context.Background(), "75532250-c878-42f1-8871-bafa68e944d4", &state)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, "75532250-c878-42f1-8871-bafa68e944d4", body.Uuid)
+	assert.Equal(t, "some user description", *body.Description)
+	assert.Equal(t, "CN=bob,DC=company,DC=world", *body.Dn)
+	assert.Equal(t, "user@email.com", *body.Email)
+	assert.Equal(t, []string{"folder-uuid"}, *body.Folders)
+	assert.Equal(t, "jdoe", *body.Identifier)
+	assert.Equal(t, "user", *body.Kind)
+	assert.True(t, *body.LocalAuth)
+	assert.Equal(t, "Some Account name", *body.Name)
+	assert.Nil(t, body.OldPassword)
+	assert.Equal(t, "$2a$10$HM7zy3pUuoyKwnaFk4A4W.9gLQZ3BGWeJqwdlPiOJN6TayLbSQ1Na", *body.Password)
+	assert.Equal(t, []smc.DefinitionsAccountsAccountUpdateRequestPermissions{"smc", "api"}, *body.Permissions)
+}
+
+func TestAccountResourceModelUpdateRequestClearsRemoved(t *testing.T) {
+	state := testAccountResourceModel(t)
+
+	data := state
+	data.Description = types.StringNull()
+	data.DN = types.StringNull()
+	data.Email = types.StringNull()
+	data.Folders = types.SetNull(types.StringType)
+	data.Identifier = types.StringNull()
+	data.Name = types.StringNull()
+	data.Permissions = types.SetNull(types.StringType)
+
+	body, diags := data.updateRequest(context.Background(), "75532250-c878-42f1-8871-bafa68e944d4", &state)
+	require.False(t, diags.HasError())
+
+	// The attributes removed from the configuration are cleared in the SMC.
+	assert.Equal(t, "", *body.Description)
+	assert.Equal(t, "", *body.Dn)
+	assert.Equal(t, "", *body.Email)
+	assert.Equal(t, []string{}, *body.Folders)
+	assert.Equal(t, "", *body.Identifier)
+	assert.Equal(t, "", *body.Name)
+	assert.Equal(t, []smc.DefinitionsAccountsAccountUpdateRequestPermissions{}, *body.Permissions)
+
+	// The attributes which were not set are still omitted.
+	state = data
+
+	body, diags = data.updateRequest(context.Background(), "75532250-c878-42f1-8871-bafa68e944d4", &state)
+	require.False(t, diags.HasError())
+
+	assert.Nil(t, body.Description)
+	assert.Nil(t, body.Dn)
+	assert.Nil(t, body.Email)
+	assert.Nil(t, body.Folders)
+	assert.Nil(t, body.Identifier)
+	assert.Nil(t, body.Name)
+	assert.Nil(t, body.Permissions)
+}
+
+func TestReadAccountResourceModelClearedValues(t *testing.T) {
+	data := AccountResourceModel{
+		Folders:     types.SetNull(types.StringType),
+		Permissions: types.SetNull(types.StringType),
+	}
+
+	empty := ""
+
+	readAccountResourceModel(&data, &smc.DefinitionsAccountsAccountPropertiesWithoutPassword{
+		Description: &empty,
+		Dn:          &empty,
+		Email:       &empty,
+		Folders:     &[]string{},
+		Identifier:  &empty,
+		Name:        &empty,
+		Permissions: &[]smc.DefinitionsAccountsAccountPropertiesWithoutPasswordPermissions{},
+		Uuid:        "75532250-c878-42f1-8871-bafa68e944d4",
+	})
+
+	// The values cleared by the SMC match the attributes removed from the
+	// configuration.
+	assert.True(t, data.Description.IsNull())
+	assert.True(t, data.DN.IsNull())
+	assert.True(t, data.Email.IsNull())
+	assert.True(t, data.Folders.IsNull())
+	assert.True(t, data.Identifier.IsNull())
+	assert.True(t, data.Name.IsNull())
+	assert.True(t, data.Permissions.IsNull())
+}
+
+func TestReadAccountResourceModelKeepsEmptyValues(t *testing.T) {
+	data := AccountResourceModel{
+		Description: types.StringValue(""),
+		DN:          types.StringValue(""),
+		Email:       types.StringValue(""),
+		Folders:     types.SetNull(types.StringType),
+		Identifier:  types.StringValue(""),
+		Name:        types.StringValue(""),
+		Permissions: types.SetNull(types.StringType),
+	}
+
+	empty := ""
+
+	readAccountResourceModel(&data, &smc.DefinitionsAccountsAccountPropertiesWithoutPassword{
+		Description: &empty,
+		Dn:          &empty,
+		Email:       &empty,
+		Identifier:  &empty,
+		Uuid:        "75532250-c878-42f1-8871-bafa68e944d4",
+	})
+
+	// The attributes set to "" in the configuration stay empty instead of
+	// becoming null.
+	for _, value := range []types.String{data.Description, data.DN, data.Email, data.Identifier, data.Name} {
+		assert.True(t, value.Equal(types.StringValue("")), value)
+	}
+}
+
+func TestReadAccountResourceModelKeepsLastUpdated(t *testing.T) {
+	data := testAccountResourceModel(t)
+	prior := data
+
+	readAccountResourceModel(&data, &smc.DefinitionsAccountsAccountPropertiesWithoutPassword{
+		Description: data.Description.ValueStringPointer(),
+		Dn:          data.DN.ValueStringPointer(),
+		Email:       data.Email.ValueStringPointer(),
+		Folders:     &[]string{"folder-uuid"},
+		Identifier:  data.Identifier.ValueStringPointer(),
+		Kind:        data.Kind.ValueStringPointer(),
+		LocalAuth:   data.LocalAuth.ValueBoolPointer(),
+		Name:        data.Name.ValueStringPointer(),
+		Permissions: &[]smc.DefinitionsAccountsAccountPropertiesWithoutPasswordPermissions{"smc", "api"},
+		Uuid:        data.UUID.ValueString(),
+	})
+
+	assert.Equal(t, prior.LastUpdated, data.LastUpdated)
+	assert.True(t, data.sameAccount(&prior))
+}
+
+func TestAccountResourceModelSameAccount(t *testing.T) {
+	prior := testAccountResourceModel(t)
+
+	data := prior
+	data.LastUpdated = types.StringValue("Tuesday, 03-Jan-06 15:04:05 MST")
+	data.Password = types.StringNull()
+	assert.True(t, data.sameAccount(&prior))
+
+	data = prior
+	data.Name = types.StringValue("Renamed outside of Terraform")
+	assert.False(t, data.sameAccount(&prior))
+
+	permissions, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"smc"})
+	require.False(t, diags.HasError())
+
+	data = prior
+	data.Permissions = permissions
+	assert.False(t, data.sameAccount(&prior))
+}
+
+func TestReadAccountResourceModelDropsDuplicates(t *testing.T) {
+	var data AccountResourceModel
+
+	readAccountResourceModel(&data, &smc.DefinitionsAccountsAccountPropertiesWithoutPassword{
+		Folders:     &[]string{"folder-uuid", "folder-uuid"},
+		Permissions: &[]smc.DefinitionsAccountsAccountPropertiesWithoutPasswordPermissions{"api", "smc", "api"},
+		Uuid:        "75532250-c878-42f1-8871-bafa68e944d4",
+	})
+
+	expectedFolders, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"folder-uuid"})
+	require.False(t, diags.HasError())
+	assert.True(t, expectedFolders.Equal(data.Folders))
+
+	// Sets are equal whatever the order of their elements.
+	expectedPermissions, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"smc", "api"})
+	require.False(t, diags.HasError())
+	assert.True(t, expectedPermissions.Equal(data.Permissions))
+}
+
+func TestAccountResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &AccountResource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+	require.NotNil(t, upgrader.PriorSchema)
+
+	folders, diags := types.ListValueFrom(ctx, types.StringType, []string{"folder-uuid"})
+	require.False(t, diags.HasError())
+
+	permissions, diags := types.ListValueFrom(ctx, types.StringType, []string{"smc", "api", "smc"})
+	require.False(t, diags.HasError())
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags = prior.Set(ctx, &AccountResourceModelV0{
+		Folders:     folders,
+		Identifier:  types.StringValue("jdoe"),
+		LastUpdated: types.StringValue("Monday, 02-Jan-06 15:04:05 MST"),
+		Permissions: permissions,
+		UUID:        types.StringValue("75532250-c878-42f1-8871-bafa68e944d4"),
+	})
+	require.False(t, diags.HasError())
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	assert.Equal(t, int64(1), schemaResp.Schema.Version)
+
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data AccountResourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	expectedFolders, diags := types.SetValueFrom(ctx, types.StringType, []string{"folder-uuid"})
+	require.False(t, diags.HasError())
+	assert.True(t, expectedFolders.Equal(data.Folders))
+
+	expectedPermissions, diags := types.SetValueFrom(ctx, types.StringType, []string{"api", "smc"})
+	require.False(t, diags.HasError())
+	assert.True(t, expectedPermissions.Equal(data.Permissions))
+
+	assert.Equal(t, "jdoe", data.Identifier.ValueString())
+	assert.Equal(t, "Monday, 02-Jan-06 15:04:05 MST", data.LastUpdated.ValueString())
+	assert.Equal(t, "75532250-c878-42f1-8871-bafa68e944d4", data.UUID.ValueString())
+	assert.True(t, data.Description.IsNull())
+}
+
+func TestAccountResourceLocalAuthUnsetKeepsState(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&AccountResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["local_auth"].(schema.BoolAttribute)
+	require.True(t, ok)
+	assert.True(t, attribute.Optional)
+	assert.True(t, attribute.Computed)
+
+	// The plan of the local_auth removed from the configuration keeps the
+	// value of the state, which is sent back to the SMC.
+	resp := planmodifier.BoolResponse{PlanValue: types.BoolUnknown()}
+	for _, modifier := range attribute.PlanModifiers {
+		modifier.PlanModifyBool(ctx, planmodifier.BoolRequest{
+			ConfigValue: types.BoolNull(),
+			PlanValue:   resp.PlanValue,
+			StateValue:  types.BoolValue(true),
+		}, &resp)
+	}
+	assert.True(t, resp.PlanValue.Equal(types.BoolValue(true)))
+
+	state := testAccountResourceModel(t)
+	data := state
+	data.LocalAuth = resp.PlanValue
+
+	body, diags := data.updateRequest(ctx, "75532250-c878-42f1-8871-bafa68e944d4", &state)
+	require.False(t, diags.HasError())
+	assert.True(t, *body.LocalAuth)
+}
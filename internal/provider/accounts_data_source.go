@@ -9,7 +9,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/trois-six/smc"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -21,7 +20,7 @@ func NewAccountsDataSource() datasource.DataSource {
 
 // AccountsDataSource defines the data source implementation.
 type AccountsDataSource struct {
-	client *smc.ClientWithResponses
+	providerData *SMCProviderData
 }
 
 // AccountsDataSourceModel describes the data source data model.
@@ -54,17 +53,17 @@ func (d *AccountsDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*smc.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*SMCProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *smc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func (d *AccountsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -77,7 +76,7 @@ func (d *AccountsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	respAPI, err := d.client.GetApiAccountsWithResponse(ctx)
+	respAPI, err := d.providerData.Client.GetApiAccountsWithResponse(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading SMC Accounts",
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAPIKeyFile(t *testing.T) {
+	apiKeyFile := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(apiKeyFile, []byte("  YOUR_API_KEY\n"), 0o600))
+
+	apiKey, err := readAPIKeyFile(apiKeyFile)
+	require.NoError(t, err)
+	assert.Equal(t, "YOUR_API_KEY", apiKey)
+
+	_, err = readAPIKeyFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.Error(t, err)
+}
+
+func TestRunAPIKeyCommand(t *testing.T) {
+	apiKey, err := runAPIKeyCommand(context.Background(), []string{"echo", "YOUR_API_KEY"})
+	require.NoError(t, err)
+	assert.Equal(t, "YOUR_API_KEY", apiKey)
+
+	_, err = runAPIKeyCommand(context.Background(), []string{"sh", "-c", "echo access denied >&2; exit 1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+
+	_, err = runAPIKeyCommand(context.Background(), []string{"does-not-exist"})
+	assert.Error(t, err)
+
+	_, err = runAPIKeyCommand(context.Background(), nil)
+	assert.Error(t, err)
+}
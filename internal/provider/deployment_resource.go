@@ -0,0 +1,439 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentResource{}
+var _ resource.ResourceWithConfigure = &DeploymentResource{}
+
+// deploymentPollInterval is the delay between two checks of the deployment
+// progress while waiting for its completion.
+var deploymentPollInterval = 5 * time.Second
+
+func NewDeploymentResource() resource.Resource {
+	return &DeploymentResource{}
+}
+
+// DeploymentResource defines the resource implementation.
+type DeploymentResource struct {
+	providerData *SMCProviderData
+}
+
+// DeploymentResourceModel describes the resource data model.
+type DeploymentResourceModel struct {
+	Comment           types.String `tfsdk:"comment"`
+	Firewalls         types.List   `tfsdk:"firewalls"`
+	Revision          types.String `tfsdk:"revision"`
+	Targets           types.Set    `tfsdk:"targets"`
+	Timeout           types.String `tfsdk:"timeout"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+}
+
+// deploymentFirewallAttrTypes describes the deployment result of a firewall.
+var deploymentFirewallAttrTypes = map[string]attr.Type{
+	"code":      types.StringType,
+	"name":      types.StringType,
+	"postponed": types.BoolType,
+	"revision":  types.StringType,
+	"state":     types.StringType,
+	"uuid":      types.StringType,
+}
+
+func (r *DeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deploy the pending configuration changes to firewalls. " +
+			"A new deployment is run each time one of the arguments changes, use `triggers` to redeploy when referenced objects change. " +
+			"Destroying this resource does not change anything on the SMC.",
+
+		Attributes: map[string]schema.Attribute{
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "The comment attached to the deployment",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"firewalls": schema.ListNestedAttribute{
+				MarkdownDescription: "Deployment result of each target firewall",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							MarkdownDescription: "Deployment code",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Firewall's name",
+							Computed:            true,
+						},
+						"postponed": schema.BoolAttribute{
+							MarkdownDescription: "Whether the firewall was disconnected when the deployment started",
+							Computed:            true,
+						},
+						"revision": schema.StringAttribute{
+							MarkdownDescription: "Revision of the configuration deployed on the firewall",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "Deployment state",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "Firewall's UUID",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "Revision number of the deployment",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"targets": schema.SetAttribute{
+				MarkdownDescription: "UUIDs of the firewalls to deploy the configuration to, or `[\"all\"]` to deploy on every firewall",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration to wait for the deployment to start and, with `wait_for_completion`, to complete, defaults to `10m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					isDuration(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will run a new deployment",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to complete on every target firewall, defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *DeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// deploymentFirewallDone tells whether the deployment on a firewall reached
+// its last step.
+func deploymentFirewallDone(step *smc.DefinitionsDeployDeploymentMonitoringResponseFirewallsStep, total *smc.DefinitionsDeployDeploymentMonitoringResponseFirewallsTotal) bool {
+	return step != nil && total != nil && int(*step) >= int(*total)
+}
+
+func readDeploymentResourceModel(data *DeploymentResourceModel, item *smc.DefinitionsDeployDeploymentMonitoringResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Revision = types.StringPointerValue(item.Revision)
+
+	firewallAttrs := []attr.Value{}
+	if item.Firewalls != nil {
+		for _, firewall := range *item.Firewalls {
+			firewallValue, d := types.ObjectValue(deploymentFirewallAttrTypes, map[string]attr.Value{
+				"code":      types.StringPointerValue(firewall.Code),
+				"name":      types.StringPointerValue(firewall.Name),
+				"postponed": types.BoolPointerValue(firewall.Postponed),
+				"revision":  types.StringPointerValue(firewall.Revision),
+				"state":     types.StringPointerValue(firewall.State),
+				"uuid":      types.StringPointerValue(firewall.Uuid),
+			})
+			diags.Append(d...)
+			firewallAttrs = append(firewallAttrs, firewallValue)
+		}
+	}
+
+	listValue, d := types.ListValue(types.ObjectType{AttrTypes: deploymentFirewallAttrTypes}, firewallAttrs)
+	diags.Append(d...)
+	data.Firewalls = listValue
+
+	return diags
+}
+
+// getDeployment returns the progress of the last deployment.
+func (r *DeploymentResource) getDeployment(ctx context.Context) (*smc.DefinitionsDeployDeploymentMonitoringResponse, error) {
+	respAPI, err := r.providerData.Client.GetApiUnifiedconfigDeployWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status code %s returned while reading the SMC deployment", respAPI.Status())
+	}
+
+	if respAPI.JSON200 == nil {
+		return nil, errors.New("no result returned while reading the SMC deployment")
+	}
+
+	return respAPI.JSON200, nil
+}
+
+// deploymentFailed tells whether the SMC reports the deployment as failed.
+func deploymentFailed(deployment *smc.DefinitionsDeployDeploymentMonitoringResponse) bool {
+	return deployment.Success != nil && !bool(*deployment.Success)
+}
+
+// deploymentStarted tells whether the last deployment is not the one which
+// preceded ours, with the given revision, and already reports its firewalls.
+func deploymentStarted(deployment *smc.DefinitionsDeployDeploymentMonitoringResponse, previousRevision *string) bool {
+	if deployment.Revision == nil || (previousRevision != nil && *deployment.Revision == *previousRevision) {
+		return false
+	}
+
+	return deployment.Firewalls != nil && len(*deployment.Firewalls) > 0
+}
+
+// waitForDeployment polls the last deployment until the one following the
+// previous revision started and, when complete is set, until it completed on
+// every firewall, failed, or the context expired.
+func (r *DeploymentResource) waitForDeployment(ctx context.Context, previousRevision *string, complete bool) (*smc.DefinitionsDeployDeploymentMonitoringResponse, error) {
+	for {
+		deployment, err := r.getDeployment(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Right after it is requested, the SMC may still report the previous
+		// deployment.
+		if deploymentStarted(deployment, previousRevision) {
+			if !complete || deploymentFailed(deployment) {
+				return deployment, nil
+			}
+
+			done := true
+			for _, firewall := range *deployment.Firewalls {
+				if !deploymentFirewallDone(firewall.Step, firewall.Total) && (firewall.Postponed == nil || !*firewall.Postponed) {
+					done = false
+				}
+			}
+
+			if done {
+				return deployment, nil
+			}
+		}
+
+		tflog.Debug(ctx, "Waiting for the deployment to complete", map[string]interface{}{"revision": deployment.Revision})
+
+		select {
+		case <-ctx.Done():
+			return deployment, ctx.Err()
+		case <-time.After(deploymentPollInterval):
+		}
+	}
+}
+
+func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var targets []string
+	resp.Diagnostics.Append(data.Targets.ElementsAs(ctx, &targets, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The revision of the previous deployment tells ours apart from it.
+	previous, err := r.getDeployment(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading the SMC Deployment",
+			"Could not read the previous SMC deployment: "+err.Error(),
+		)
+		return
+	}
+
+	respAPI, err := r.providerData.Client.PostApiUnifiedconfigDeployWithResponse(ctx, smc.PostApiUnifiedconfigDeployJSONRequestBody{
+		Comment: data.Comment.ValueStringPointer(),
+		Target:  targets,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deploying the SMC Configuration",
+			"Could not deploy the SMC configuration to "+strings.Join(targets, ", ")+": "+err.Error(),
+		)
+		return
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"HTTP Error Deploying the SMC Configuration",
+			"HTTP status code "+respAPI.Status()+" returned while deploying the SMC configuration",
+		)
+		return
+	}
+
+	if respAPI.JSON200 != nil && respAPI.JSON200.Success != nil && !bool(*respAPI.JSON200.Success) {
+		resp.Diagnostics.AddError(
+			"SMC Deployment Failed",
+			"The SMC refused to deploy the configuration to "+strings.Join(targets, ", "),
+		)
+		return
+	}
+
+	// The timeout has already been validated by the schema validator.
+	timeout, _ := time.ParseDuration(data.Timeout.ValueString())
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deployment, err := r.waitForDeployment(waitCtx, previous.Revision, data.WaitForCompletion.ValueBool())
+	if errors.Is(err, context.DeadlineExceeded) {
+		resp.Diagnostics.AddError(
+			"Timeout Waiting for the SMC Deployment",
+			"The SMC deployment did not complete within "+data.Timeout.ValueString(),
+		)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Waiting for the SMC Deployment",
+			"Could not read the SMC deployment progress: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(readDeploymentResourceModel(&data, deployment)...)
+
+	// The SMC only reports the failure of the whole deployment, it failed on
+	// the firewalls which did not reach their last step.
+	failedFirewalls := 0
+	if deploymentFailed(deployment) && deployment.Firewalls != nil {
+		for _, firewall := range *deployment.Firewalls {
+			if deploymentFirewallDone(firewall.Step, firewall.Total) {
+				continue
+			}
+
+			failedFirewalls++
+
+			resp.Diagnostics.AddError(
+				"SMC Deployment Failed on Firewall "+types.StringPointerValue(firewall.Name).ValueString(),
+				fmt.Sprintf("The deployment failed on firewall %s (UUID %s) with state %q and code %q",
+					types.StringPointerValue(firewall.Name).ValueString(),
+					types.StringPointerValue(firewall.Uuid).ValueString(),
+					types.StringPointerValue(firewall.State).ValueString(),
+					types.StringPointerValue(firewall.Code).ValueString(),
+				),
+			)
+		}
+	}
+
+	if failedFirewalls == 0 && deploymentFailed(deployment) {
+		resp.Diagnostics.AddError(
+			"SMC Deployment Failed",
+			"The SMC reports the deployment of revision "+data.Revision.ValueString()+" as failed",
+		)
+	}
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Deployed the configuration", map[string]interface{}{"revision": data.Revision})
+
+	// Save data into Terraform state, the resource is tainted if the
+	// deployment failed so that it is run again on next apply.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A deployment is a one-off operation: the SMC only reports the last one,
+	// which may have been run by someone else, so the state is kept as is.
+}
+
+func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DeploymentResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the waiting settings can be updated in place, they do not trigger a
+	// new deployment.
+	data.Firewalls = state.Firewalls
+	data.Revision = state.Revision
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A deployment cannot be undone, it is only removed from the state.
+}
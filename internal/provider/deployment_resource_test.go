@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trois-six/smc"
+)
+
+// testAccDeploymentServer returns a SMC whose deployments end with the given
+// firewall state and step, out of 3, and success flag. The first poll
+// following a deployment still reports the previous one.
+func testAccDeploymentServer(t *testing.T, state string, lastStep int, success bool) *httptest.Server {
+	previousRevision, revision := 41, 41
+	polls := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		var err error
+		switch r.Method {
+		case http.MethodPost:
+			previousRevision = revision
+			revision++
+			polls = 0
+			_, err = w.Write([]byte(`{"success": true}`))
+		case http.MethodGet:
+			deploymentRevision, deploymentState, deploymentSuccess, step := revision, state, success, lastStep
+
+			switch polls {
+			case 0:
+				// The previous deployment, already completed.
+				deploymentRevision, deploymentState, deploymentSuccess, step = previousRevision, "success", true, 3
+			case 1:
+				// The deployment completes on the next poll.
+				deploymentState, deploymentSuccess, step = "deploying", true, 1
+			}
+			polls++
+
+			_, err = fmt.Fprintf(w, `{
+  "revision": "%[1]d",
+  "success": %[2]t,
+  "user": "admin",
+  "firewalls": [
+    {
+      "uuid": "firewall-uuid",
+      "name": "sns-paris",
+      "code": "DEPLOY",
+      "state": %[3]q,
+      "revision": "%[1]d",
+      "postponed": false,
+      "step": %[4]d,
+      "total": 3
+    }
+  ]
+}`, deploymentRevision, deploymentSuccess, deploymentState, step)
+		}
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
+	}))
+}
+
+func TestAccDeploymentResource(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+
+	testServer := testAccDeploymentServer(t, "success", 3, true)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccDeploymentResourceConfig("v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_deployment.paris", "revision", "42"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "firewalls.0.revision", "42"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "timeout", "10m"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "wait_for_completion", "true"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "firewalls.#", "1"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "firewalls.0.name", "sns-paris"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "firewalls.0.state", "success"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "firewalls.0.uuid", "firewall-uuid"),
+				),
+			},
+			// Replace testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccDeploymentResourceConfig("v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_deployment.paris", "triggers.version", "v2"),
+					resource.TestCheckResourceAttr("smc_deployment.paris", "revision", "43"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDeploymentResourceFailure(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+
+	testServer := testAccDeploymentServer(t, "error", 2, false)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(providerConfig, testServer.URL) + testAccDeploymentResourceConfig("v1"),
+				ExpectError: regexp.MustCompile("SMC Deployment Failed on Firewall sns-paris"),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResourceUnsuccessful(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+
+	// Every firewall reached its last step, only the success flag reports
+	// the failure.
+	testServer := testAccDeploymentServer(t, "done", 3, false)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(providerConfig, testServer.URL) + testAccDeploymentResourceConfig("v1"),
+				ExpectError: regexp.MustCompile("SMC Deployment Failed"),
+			},
+		},
+	})
+}
+
+func testAccDeploymentResourceConfig(version string) string {
+	return fmt.Sprintf(`
+resource "smc_deployment" "paris" {
+  targets = ["firewall-uuid"]
+  comment = "Deployed by Terraform"
+
+  triggers = {
+    version = %[1]q
+  }
+}
+`, version)
+}
+
+func TestWaitForDeploymentSkipsPreviousDeployment(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+
+	testServer := testAccDeploymentServer(t, "success", 3, true)
+	defer testServer.Close()
+
+	client, err := smc.NewClientWithResponses(testServer.URL)
+	require.NoError(t, err)
+
+	r := &DeploymentResource{providerData: &SMCProviderData{Client: client}}
+
+	previous, err := r.getDeployment(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "41", *previous.Revision)
+
+	_, err = client.PostApiUnifiedconfigDeployWithResponse(context.Background(), smc.PostApiUnifiedconfigDeployJSONRequestBody{Target: []string{"firewall-uuid"}})
+	require.NoError(t, err)
+
+	for _, complete := range []bool{false, true} {
+		deployment, err := r.waitForDeployment(context.Background(), previous.Revision, complete)
+		require.NoError(t, err)
+		assert.Equal(t, "42", *deployment.Revision)
+	}
+}
+
+func TestWaitForDeploymentStopsOnFailure(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+
+	testServer := testAccDeploymentServer(t, "error", 2, false)
+	defer testServer.Close()
+
+	client, err := smc.NewClientWithResponses(testServer.URL)
+	require.NoError(t, err)
+
+	r := &DeploymentResource{providerData: &SMCProviderData{Client: client}}
+
+	previous, err := r.getDeployment(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.PostApiUnifiedconfigDeployWithResponse(context.Background(), smc.PostApiUnifiedconfigDeployJSONRequestBody{Target: []string{"firewall-uuid"}})
+	require.NoError(t, err)
+
+	// The failed deployment is returned although the firewall did not reach
+	// its last step.
+	deployment, err := r.waitForDeployment(context.Background(), previous.Revision, true)
+	require.NoError(t, err)
+	assert.Equal(t, "42", *deployment.Revision)
+	assert.True(t, deploymentFailed(deployment))
+
+	firewall := (*deployment.Firewalls)[0]
+	assert.False(t, deploymentFirewallDone(firewall.Step, firewall.Total))
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationValidator(t *testing.T) {
+	for value, wantError := range map[string]bool{
+		"30s":   false,
+		"10m":   false,
+		"1h30m": false,
+		"0s":    true,
+		"-1m":   true,
+		"10":    true,
+		"ten":   true,
+	} {
+		resp := &validator.StringResponse{}
+		isDuration().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("timeout"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		assert.Equal(t, wantError, resp.Diagnostics.HasError(), value)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FirewallsDataSource{}
+
+func NewFirewallsDataSource() datasource.DataSource {
+	return &FirewallsDataSource{}
+}
+
+// FirewallsDataSource defines the data source implementation.
+type FirewallsDataSource struct {
+	providerData *SMCProviderData
+}
+
+// FirewallsDataSourceModel describes the data source data model.
+type FirewallsDataSourceModel struct {
+	Folder    types.String              `tfsdk:"folder"`
+	Firewalls []FirewallDataSourceModel `tfsdk:"firewalls"`
+}
+
+// FirewallDataSourceModel describes a firewall of the data source data model.
+type FirewallDataSourceModel struct {
+	Folder types.String `tfsdk:"folder"`
+	UUID   types.String `tfsdk:"uuid"`
+}
+
+func (d *FirewallsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewalls"
+}
+
+func (d *FirewallsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches all the firewalls of a folder and its sub-folders.",
+		Attributes: map[string]schema.Attribute{
+			"folder": schema.StringAttribute{
+				MarkdownDescription: "UUID of the folder to list the firewalls of, including its sub-folders. Defaults to the root folder.",
+				Optional:            true,
+			},
+			"firewalls": schema.ListNestedAttribute{
+				Description: "List of firewalls",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"folder": schema.StringAttribute{
+							MarkdownDescription: "UUID of the folder containing the firewall",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "Firewall uuid",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FirewallsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// findFolderByUUID returns the folder with the given UUID within the folder
+// tree, or nil if it does not exist.
+func findFolderByUUID(folder *smc.DefinitionsFoldersFolderMember, uuid string) *smc.DefinitionsFoldersFolderMember {
+	if folder.Uuid == uuid {
+		return folder
+	}
+
+	for idx := range folder.Children {
+		if found := findFolderByUUID(&folder.Children[idx], uuid); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// readFirewallsDataSourceModel appends the firewalls of the folder and all
+// its sub-folders to the firewalls slice.
+func readFirewallsDataSourceModel(firewalls []FirewallDataSourceModel, folder *smc.DefinitionsFoldersFolderMember) []FirewallDataSourceModel {
+	if folder.Firewalls != nil {
+		for _, firewall := range *folder.Firewalls {
+			firewalls = append(firewalls, FirewallDataSourceModel{
+				Folder: types.StringValue(folder.Uuid),
+				UUID:   types.StringValue(firewall),
+			})
+		}
+	}
+
+	for idx := range folder.Children {
+		firewalls = readFirewallsDataSourceModel(firewalls, &folder.Children[idx])
+	}
+
+	return firewalls
+}
+
+func (d *FirewallsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FirewallsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	root, err := getFolderTree(ctx, d.providerData.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SMC Firewalls",
+			"Could not read the SMC folder tree: "+err.Error(),
+		)
+		return
+	}
+
+	folder := root
+	if !data.Folder.IsNull() {
+		folder = findFolderByUUID(root, data.Folder.ValueString())
+		if folder == nil {
+			resp.Diagnostics.AddError(
+				"No results Reading SMC Firewalls",
+				"No folder found for given UUID: "+data.Folder.ValueString(),
+			)
+			return
+		}
+	}
+
+	data.Firewalls = readFirewallsDataSourceModel([]FirewallDataSourceModel{}, folder)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirewallsDataSource(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+  "result": {
+    "uuid": "root-uuid",
+    "name": "MySMC",
+    "firewalls": [
+      "root-firewall-uuid"
+    ],
+    "children": [
+      {
+        "uuid": "europe-uuid",
+        "name": "Europe",
+        "firewalls": [
+          "europe-firewall-uuid"
+        ],
+        "children": [
+          {
+            "uuid": "paris-uuid",
+            "name": "Paris",
+            "firewalls": [
+              "paris-firewall-uuid"
+            ],
+            "children": []
+          }
+        ]
+      }
+    ]
+  },
+  "success": true
+}`))
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
+	}))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + `
+data "smc_firewalls" "all" {}
+
+data "smc_firewalls" "europe" {
+  folder = "europe-uuid"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.smc_firewalls.all", "firewalls.#", "3"),
+					resource.TestCheckResourceAttr("data.smc_firewalls.europe", "firewalls.#", "2"),
+					resource.TestCheckResourceAttr("data.smc_firewalls.europe", "firewalls.0.folder", "europe-uuid"),
+					resource.TestCheckResourceAttr("data.smc_firewalls.europe", "firewalls.0.uuid", "europe-firewall-uuid"),
+					resource.TestCheckResourceAttr("data.smc_firewalls.europe", "firewalls.1.folder", "paris-uuid"),
+					resource.TestCheckResourceAttr("data.smc_firewalls.europe", "firewalls.1.uuid", "paris-firewall-uuid"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FolderDataSource{}
+
+func NewFolderDataSource() datasource.DataSource {
+	return &FolderDataSource{}
+}
+
+// FolderDataSource defines the data source implementation.
+type FolderDataSource struct {
+	providerData *SMCProviderData
+}
+
+// FolderDataSourceModel describes the data source data model.
+type FolderDataSourceModel struct {
+	Description  types.String `tfsdk:"description"`
+	Firewalls    types.List   `tfsdk:"firewalls"`
+	Name         types.String `tfsdk:"name"`
+	ParentFolder types.String `tfsdk:"parent_folder"`
+	Path         types.String `tfsdk:"path"`
+	UUID         types.String `tfsdk:"uuid"`
+}
+
+func (d *FolderDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+func (d *FolderDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a folder based on its path in the folder tree.",
+		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Folder's description",
+				Computed:            true,
+			},
+			"firewalls": schema.ListAttribute{
+				MarkdownDescription: "List of the firewalls UUID contained by this folder",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Folder's name",
+				Computed:            true,
+			},
+			"parent_folder": schema.StringAttribute{
+				MarkdownDescription: "Parent folder's UUID (empty for the root folder)",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Slash-separated path of the folder starting below the root folder, e.g. `Europe/Paris`. An empty path or `/` designates the root folder.",
+				Required:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Folder uuid",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *FolderDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// getFolderTree returns the root folder of the SMC folder tree with all its
+// sub-folders nested.
+func getFolderTree(ctx context.Context, client *smc.ClientWithResponses) (*smc.DefinitionsFoldersFolderMember, error) {
+	nested := true
+
+	respAPI, err := client.GetApiFoldersWithResponse(ctx, &smc.GetApiFoldersParams{Nested: &nested})
+	if err != nil {
+		return nil, err
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status code %s returned while reading the SMC folder tree", respAPI.Status())
+	}
+
+	if respAPI.JSON200 == nil {
+		return nil, fmt.Errorf("no result returned while reading the SMC folder tree")
+	}
+
+	return &respAPI.JSON200.Result, nil
+}
+
+// findFolderByPath walks the folder tree from the root folder following the
+// slash-separated folder names of path. It returns the matching folder and
+// the UUID of its parent, which is empty for the root folder.
+func findFolderByPath(root *smc.DefinitionsFoldersFolderMember, path string) (*smc.DefinitionsFoldersFolderMember, string, error) {
+	folder := root
+	parent := ""
+
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			continue
+		}
+
+		var next *smc.DefinitionsFoldersFolderMember
+
+		for idx := range folder.Children {
+			if folder.Children[idx].Name == name {
+				next = &folder.Children[idx]
+				break
+			}
+		}
+
+		if next == nil {
+			return nil, "", fmt.Errorf("folder %q not found under %q", name, folder.Name)
+		}
+
+		parent = folder.Uuid
+		folder = next
+	}
+
+	return folder, parent, nil
+}
+
+func readFolderDataSourceModel(data *FolderDataSourceModel, item *smc.DefinitionsFoldersFolderMember, parent string) {
+	data.Description = types.StringPointerValue(item.Comment)
+
+	firewallAttrs := []attr.Value{}
+	if item.Firewalls != nil {
+		for _, firewall := range *item.Firewalls {
+			firewallAttrs = append(firewallAttrs, types.StringValue(firewall))
+		}
+	}
+
+	listValue, _ := types.ListValue(types.StringType, firewallAttrs)
+	data.Firewalls = listValue
+
+	data.Name = types.StringValue(item.Name)
+	data.ParentFolder = types.StringValue(parent)
+	data.UUID = types.StringValue(item.Uuid)
+}
+
+func (d *FolderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FolderDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	root, err := getFolderTree(ctx, d.providerData.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SMC Folders",
+			"Could not read the SMC folder tree: "+err.Error(),
+		)
+		return
+	}
+
+	folder, parent, err := findFolderByPath(root, data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"No results Reading SMC Folder",
+			"No folder found for given path "+data.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	readFolderDataSourceModel(&data, folder, parent)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
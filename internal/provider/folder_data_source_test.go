@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trois-six/smc"
+)
+
+const testFolderTree = `{
+  "result": {
+    "uuid": "root-uuid",
+    "name": "MySMC",
+    "children": [
+      {
+        "uuid": "europe-uuid",
+        "name": "Europe",
+        "children": [
+          {
+            "uuid": "paris-uuid",
+            "name": "Paris",
+            "comment": "Paris offices",
+            "firewalls": [
+              "firewall-uuid"
+            ],
+            "children": []
+          }
+        ]
+      }
+    ]
+  },
+  "success": true
+}`
+
+func TestFindFolderByPath(t *testing.T) {
+	root := &smc.DefinitionsFoldersFolderMember{
+		Uuid: "root-uuid",
+		Name: "MySMC",
+		Children: []smc.DefinitionsFoldersFolderMember{
+			{
+				Uuid: "europe-uuid",
+				Name: "Europe",
+				Children: []smc.DefinitionsFoldersFolderMember{
+					{Uuid: "paris-uuid", Name: "Paris"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		path   string
+		uuid   string
+		parent string
+	}{
+		{path: "", uuid: "root-uuid", parent: ""},
+		{path: "/", uuid: "root-uuid", parent: ""},
+		{path: "Europe", uuid: "europe-uuid", parent: "root-uuid"},
+		{path: "/Europe/Paris/", uuid: "paris-uuid", parent: "europe-uuid"},
+	} {
+		folder, parent, err := findFolderByPath(root, tc.path)
+		require.NoError(t, err, tc.path)
+		assert.Equal(t, tc.uuid, folder.Uuid, tc.path)
+		assert.Equal(t, tc.parent, parent, tc.path)
+	}
+
+	_, _, err := findFolderByPath(root, "Europe/London")
+	assert.Error(t, err)
+}
+
+func TestAccFolderDataSource(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(testFolderTree))
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
+	}))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + `
+data "smc_folder" "paris" {
+  path = "Europe/Paris"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "description", "Paris offices"),
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "firewalls.#", "1"),
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "firewalls.0", "firewall-uuid"),
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "name", "Paris"),
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "parent_folder", "europe-uuid"),
+					resource.TestCheckResourceAttr("data.smc_folder.paris", "uuid", "paris-uuid"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,391 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FolderResource{}
+var _ resource.ResourceWithConfigure = &FolderResource{}
+var _ resource.ResourceWithImportState = &FolderResource{}
+var _ resource.ResourceWithModifyPlan = &FolderResource{}
+
+func NewFolderResource() resource.Resource {
+	return &FolderResource{}
+}
+
+// FolderResource defines the resource implementation.
+type FolderResource struct {
+	providerData *SMCProviderData
+}
+
+// FolderResourceModel describes the resource data model.
+type FolderResourceModel struct {
+	Description  types.String `tfsdk:"description"`
+	Name         types.String `tfsdk:"name"`
+	ParentFolder types.String `tfsdk:"parent_folder"`
+	UUID         types.String `tfsdk:"uuid"`
+}
+
+func (r *FolderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+func (r *FolderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manage a folder of the SMC folder tree.",
+
+		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Folder's description",
+				Optional:            true,
+				Validators: []validator.String{
+					// The SMC does not tell an empty comment from no comment.
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Folder's name",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+$`),
+						"Folder name must not be empty nor contain a slash",
+					),
+				},
+			},
+			"parent_folder": schema.StringAttribute{
+				MarkdownDescription: "Parent folder's UUID, defaults to the root folder. Removing it from the configuration moves the folder to the root folder",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Folder uuid",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FolderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan plans the move of the folder to the root folder when
+// parent_folder is removed from the configuration, the prior parent folder
+// being kept otherwise.
+func (r *FolderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The root folder is resolved by Create for a new folder, nothing is
+	// planned when the folder is destroyed or the provider is not configured
+	// yet.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var parentFolder types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("parent_folder"), &parentFolder)...)
+
+	if resp.Diagnostics.HasError() || !parentFolder.IsNull() {
+		return
+	}
+
+	root, err := getFolderTree(ctx, r.providerData.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading the SMC Root Folder",
+			"Could not read the SMC root folder to plan the parent folder: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("parent_folder"), root.Uuid)...)
+}
+
+func readFolderResourceModel(data *FolderResourceModel, item *smc.DefinitionsFoldersRawFolderProperties) {
+	data.Description = types.StringNull()
+	if item.Comment != nil && *item.Comment != "" {
+		data.Description = types.StringValue(*item.Comment)
+	}
+
+	data.Name = types.StringValue(item.Name)
+	data.ParentFolder = types.StringValue(item.ParentFolder)
+	data.UUID = types.StringValue(item.Uuid)
+}
+
+func (r *FolderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FolderResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentFolder := data.ParentFolder.ValueString()
+	if data.ParentFolder.IsNull() || data.ParentFolder.IsUnknown() {
+		root, err := getFolderTree(ctx, r.providerData.Client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading the SMC Root Folder",
+				"Could not read the SMC root folder to create the folder "+data.Name.ValueString()+" in: "+err.Error(),
+			)
+			return
+		}
+
+		parentFolder = root.Uuid
+	}
+
+	respAPI, err := r.providerData.Client.PostApiFoldersWithResponse(ctx, smc.PostApiFoldersJSONRequestBody{
+		Comment:      data.Description.ValueStringPointer(),
+		Name:         data.Name.ValueString(),
+		ParentFolder: parentFolder,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating the SMC Folder",
+			"Could not create the SMC folder "+data.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if respAPI.StatusCode() != http.StatusCreated {
+		resp.Diagnostics.AddError(
+			"HTTP Error Creating the SMC Folder",
+			"HTTP status code "+respAPI.Status()+" returned while creating the SMC folder",
+		)
+		return
+	}
+
+	if respAPI.JSON201 == nil {
+		resp.Diagnostics.AddError(
+			"No results Reading response after creating the SMC Folder",
+			"No results returned after creating the SMC Folder",
+		)
+		return
+	}
+
+	readFolderResourceModel(&data, &respAPI.JSON201.Result)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Created a folder", map[string]interface{}{"uuid": data.UUID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FolderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FolderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respAPI, err := r.providerData.Client.GetApiFoldersUuidWithResponse(ctx, data.UUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading the SMC Folder",
+			"Could not read the SMC folder with UUID "+data.UUID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The folder was deleted outside of Terraform, it is planned for creation
+	// again.
+	if respAPI.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "Folder not found, removing it from the state", map[string]interface{}{"uuid": data.UUID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"HTTP Error Reading the SMC Folder",
+			"HTTP status code "+respAPI.Status()+" returned while reading the SMC folder",
+		)
+		return
+	}
+
+	if respAPI.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"No result Reading the SMC Folder",
+			"No result returned after reading the SMC Folder",
+		)
+		return
+	}
+
+	readFolderResourceModel(&data, &respAPI.JSON200.Result)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Read a folder", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FolderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state FolderResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentFolder := data.ParentFolder.ValueString()
+	if data.ParentFolder.IsNull() || data.ParentFolder.IsUnknown() {
+		parentFolder = state.ParentFolder.ValueString()
+	}
+
+	// An empty comment clears the description removed from the
+	// configuration, which the SMC would keep otherwise.
+	comment := data.Description.ValueString()
+
+	respAPI, err := r.providerData.Client.PutApiFoldersUuidWithResponse(ctx, state.UUID.ValueString(), smc.PutApiFoldersUuidJSONRequestBody{
+		Comment:      &comment,
+		Name:         data.Name.ValueString(),
+		ParentFolder: parentFolder,
+		Uuid:         state.UUID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating the SMC Folder",
+			"Could not update the SMC folder UUID "+state.UUID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"HTTP Error Updating the SMC Folder",
+			"HTTP status code "+respAPI.Status()+" returned while updating the SMC folder",
+		)
+		return
+	}
+
+	if respAPI.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"No results Reading response after updating the SMC Folder",
+			"No results returned after updating the SMC Folder",
+		)
+		return
+	}
+
+	readFolderResourceModel(&data, &respAPI.JSON200.Result)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Updated a folder", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FolderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FolderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respAPI, err := r.providerData.Client.DeleteApiFoldersUuidWithResponse(ctx, data.UUID.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting the SMC Folder",
+			"Could not delete the SMC folder UUID "+data.UUID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The folder was already deleted outside of Terraform.
+	if respAPI.StatusCode() == http.StatusNotFound {
+		tflog.Trace(ctx, "Folder already deleted", map[string]interface{}{"uuid": data.UUID})
+		return
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"HTTP Error Deleting the SMC Folder",
+			"HTTP status code "+respAPI.Status()+" returned while deleting the SMC folder",
+		)
+		return
+	}
+}
+
+// ImportState accepts either the UUID of the folder or its slash-separated
+// path in the folder tree, e.g. Europe/Paris. An ID without slash is taken as
+// a UUID, the path of a top-level folder starts with a slash, e.g. /Europe.
+func (r *FolderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.Contains(req.ID, "/") {
+		resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+		return
+	}
+
+	root, err := getFolderTree(ctx, r.providerData.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SMC Folders",
+			"Could not read the SMC folder tree: "+err.Error(),
+		)
+		return
+	}
+
+	folder, _, err := findFolderByPath(root, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing the SMC Folder",
+			"No folder found for given path "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), folder.Uuid)...)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/trois-six/smc"
+)
+
+func TestAccFolderResource(t *testing.T) {
+	folder := map[string]any{
+		"uuid":         "paris-uuid",
+		"name":         "Paris",
+		"parentFolder": "root-uuid",
+	}
+
+	// deleted is set when the folder is deleted outside of Terraform.
+	var deleted atomic.Bool
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var body map[string]any
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testFolderTree))
+			return
+		case deleted.Load():
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": "ENOTFOUND", "message": "folder not found"}]}`))
+			return
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("error reading body: %s", err)
+			}
+			folder["name"] = body["name"]
+			folder["comment"] = body["comment"]
+			folder["parentFolder"] = body["parentFolder"]
+		}
+
+		status := http.StatusOK
+		if r.Method == http.MethodPost {
+			status = http.StatusCreated
+		}
+
+		w.WriteHeader(status)
+		err := json.NewEncoder(w).Encode(map[string]any{"result": folder, "success": true})
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
+	}))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccFolderResourceConfig("Paris offices"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_folder.paris", "description", "Paris offices"),
+					resource.TestCheckResourceAttr("smc_folder.paris", "name", "Paris"),
+					resource.TestCheckResourceAttr("smc_folder.paris", "parent_folder", "root-uuid"),
+					resource.TestCheckResourceAttr("smc_folder.paris", "uuid", "paris-uuid"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "smc_folder.paris",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by path testing
+			{
+				ResourceName:      "smc_folder.paris",
+				ImportState:       true,
+				ImportStateId:     "Europe/Paris",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "smc_folder.paris",
+				ImportState:       true,
+				ImportStateId:     "/Europe/Paris",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccFolderResourceConfig("Paris headquarters"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_folder.paris", "description", "Paris headquarters"),
+				),
+			},
+			// The description removed from the configuration is cleared.
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_folder" "paris" {
+  name = "Paris"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("smc_folder.paris", "description"),
+				),
+			},
+			// The folder is moved to another parent folder.
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_folder" "paris" {
+  name          = "Paris"
+  parent_folder = "europe-uuid"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_folder.paris", "parent_folder", "europe-uuid"),
+				),
+			},
+			// The folder is moved back to the root folder when parent_folder
+			// is removed from the configuration.
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + `
+resource "smc_folder" "paris" {
+  name = "Paris"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_folder.paris", "parent_folder", "root-uuid"),
+				),
+			},
+			// The folder is deleted in the SMC console, the plan creates it
+			// again instead of failing.
+			{
+				PreConfig:          func() { deleted.Store(true) },
+				Config:             fmt.Sprintf(providerConfig, testServer.URL) + testAccFolderResourceConfig("Paris headquarters"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The destroy of the folder already deleted succeeds.
+		},
+	})
+}
+
+func testAccFolderResourceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "smc_folder" "paris" {
+  name        = "Paris"
+  description = %[1]q
+}
+`, description)
+}
+
+func TestReadFolderResourceModelEmptyComment(t *testing.T) {
+	comment := ""
+
+	for name, item := range map[string]smc.DefinitionsFoldersRawFolderProperties{
+		"no comment":    {Uuid: "paris-uuid", Name: "Paris", ParentFolder: "europe-uuid"},
+		"empty comment": {Uuid: "paris-uuid", Name: "Paris", ParentFolder: "europe-uuid", Comment: &comment},
+	} {
+		var data FolderResourceModel
+
+		readFolderResourceModel(&data, &item)
+		assert.True(t, data.Description.IsNull(), name)
+		assert.Equal(t, "paris-uuid", data.UUID.ValueString(), name)
+	}
+}
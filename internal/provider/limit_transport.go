@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Ensure limitTransport satisfies the http.RoundTripper interface.
+var _ http.RoundTripper = &limitTransport{}
+
+// limitTransport caps the number of requests in flight and the rate at which
+// they are sent to the SMC. It is shared by every resource and data source
+// through the SMC client, whatever the Terraform parallelism.
+type limitTransport struct {
+	transport http.RoundTripper
+
+	// slots holds a token per request in flight, it is nil when the number of
+	// concurrent requests is not limited.
+	slots chan struct{}
+	// interval is the minimum time between the start of two requests, 0 when
+	// the rate is not limited.
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newLimitTransport returns a transport sending at most maxConcurrent
+// requests at once and requestsPerSecond requests per second, a zero value
+// disabling the corresponding limit.
+func newLimitTransport(transport http.RoundTripper, maxConcurrent int, requestsPerSecond float64) *limitTransport {
+	t := &limitTransport{transport: transport}
+
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+
+	return t
+}
+
+// wait blocks until the request is allowed by the rate limit.
+func (t *limitTransport) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case t.slots <- struct{}{}:
+		}
+	}
+
+	release := func() {
+		if t.slots != nil {
+			<-t.slots
+		}
+	}
+
+	if err := t.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request is in flight until its response body is closed.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releaseOnClose calls release once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/trois-six/smc"
+)
+
+// SMCProviderData is the data shared by the provider with the resources and
+// data sources through their Configure methods. Resources keep a reference
+// to it, rather than to the SMC client only, to consult the provider-wide
+// settings.
+type SMCProviderData struct {
+	Client *smc.ClientWithResponses
+	// Version is the SMC version, nil when it is unknown, e.g. when
+	// validate_on_configure is not set.
+	Version *version.Version
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry settings used when they are not set in the provider
+// configuration.
+const (
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// Ensure retryTransport satisfies the http.RoundTripper interface.
+var _ http.RoundTripper = &retryTransport{}
+
+// retryTransport retries the requests failing with a network error or a
+// transient HTTP status, waiting with an exponential backoff between the
+// attempts.
+type retryTransport struct {
+	transport http.RoundTripper
+
+	// maxRetries is the number of retries after the first attempt.
+	maxRetries int
+	// waitMin and waitMax bound the exponential backoff. A Retry-After header
+	// sent by the SMC takes precedence over them.
+	waitMin time.Duration
+	waitMax time.Duration
+	// retryNonIdempotent allows retrying POST and PATCH requests, which may
+	// then be applied twice by the SMC.
+	retryNonIdempotent bool
+}
+
+// isIdempotentMethod reports whether a request with the given method can be
+// safely sent again.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableError reports whether a request failing with err may succeed
+// when sent again. TLS verification errors are permanent.
+func isRetryableError(err error) bool {
+	var (
+		verificationErr *tls.CertificateVerificationError
+		unknownAuthErr  x509.UnknownAuthorityError
+		invalidErr      x509.CertificateInvalidError
+		hostnameErr     x509.HostnameError
+	)
+
+	return !errors.As(err, &verificationErr) &&
+		!errors.As(err, &unknownAuthErr) &&
+		!errors.As(err, &invalidErr) &&
+		!errors.As(err, &hostnameErr)
+}
+
+// isRetryableStatus reports whether the SMC answered with a transient error.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter returns the wait requested by a Retry-After header, given
+// either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
+
+// backoff returns the wait before the retry following the given attempt,
+// counted from 0.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+
+	wait := t.waitMin
+	for i := 0; i < attempt && wait < t.waitMax; i++ {
+		wait *= 2
+	}
+
+	return min(wait, t.waitMax)
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Requests with a body which cannot be read again are sent only once.
+	retryable := (t.retryNonIdempotent || isIdempotentMethod(req.Method)) &&
+		(req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+
+		var retry bool
+		if err != nil {
+			retry = ctx.Err() == nil && isRetryableError(err)
+		} else {
+			retry = isRetryableStatus(resp.StatusCode)
+		}
+
+		if !retryable || !retry || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.Redacted(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.Status
+
+			// Drain the body so that the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Warn(ctx, "Retrying SMC API request", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryServer returns a server answering the given statuses in order,
+// then 200, and the number of requests it received.
+func testRetryServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && string(body) != "{}" {
+			t.Errorf("Unexpected request body %q", body)
+		}
+
+		i := int(requests.Add(1)) - 1
+		if i < len(statuses) {
+			w.WriteHeader(statuses[i])
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(testServer.Close)
+
+	return testServer, &requests
+}
+
+func TestRetryTransport(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method             string
+		statuses           []int
+		retryNonIdempotent bool
+		wantStatus         int
+		wantRequests       int32
+	}{
+		"success": {
+			method:       http.MethodGet,
+			wantStatus:   http.StatusOK,
+			wantRequests: 1,
+		},
+		"transient errors": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusTooManyRequests},
+			wantStatus:   http.StatusOK,
+			wantRequests: 4,
+		},
+		"too many transient errors": {
+			method:       http.MethodDelete,
+			statuses:     []int{503, 503, 503, 503, 503},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 4,
+		},
+		"permanent error": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusInternalServerError},
+			wantStatus:   http.StatusInternalServerError,
+			wantRequests: 1,
+		},
+		"non idempotent": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		"non idempotent allowed": {
+			method:             http.MethodPost,
+			statuses:           []int{http.StatusServiceUnavailable},
+			retryNonIdempotent: true,
+			wantStatus:         http.StatusOK,
+			wantRequests:       2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testServer, requests := testRetryServer(t, tc.statuses...)
+
+			client := &http.Client{
+				Transport: &retryTransport{
+					transport:          http.DefaultTransport,
+					maxRetries:         3,
+					waitMin:            time.Millisecond,
+					waitMax:            time.Millisecond,
+					retryNonIdempotent: tc.retryNonIdempotent,
+				},
+			}
+
+			req, err := http.NewRequest(tc.method, testServer.URL, strings.NewReader("{}"))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantRequests, requests.Load())
+		})
+	}
+}
+
+func TestRetryTransportNetworkError(t *testing.T) {
+	testServer, _ := testRetryServer(t)
+	testServer.Close()
+
+	var attempts atomic.Int32
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+			maxRetries: 2,
+			waitMin:    time.Millisecond,
+			waitMax:    time.Millisecond,
+		},
+	}
+
+	_, err := client.Get(testServer.URL)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestRetryTransportCanceled(t *testing.T) {
+	testServer, requests := testRetryServer(t, http.StatusServiceUnavailable)
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport:  http.DefaultTransport,
+			maxRetries: 3,
+			waitMin:    time.Hour,
+			waitMax:    time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req) //nolint:bodyclose // no response is returned on error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &retryTransport{waitMin: time.Second, waitMax: 5 * time.Second}
+
+	assert.Equal(t, time.Second, transport.backoff(0, nil))
+	assert.Equal(t, 2*time.Second, transport.backoff(1, nil))
+	assert.Equal(t, 4*time.Second, transport.backoff(2, nil))
+	assert.Equal(t, 5*time.Second, transport.backoff(3, nil))
+	assert.Equal(t, 5*time.Second, transport.backoff(100, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"42"}}}
+	assert.Equal(t, 42*time.Second, transport.backoff(0, resp))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for value, want := range map[string]struct {
+		wait time.Duration
+		ok   bool
+	}{
+		"":                              {0, false},
+		"120":                           {2 * time.Minute, true},
+		"-1":                            {0, false},
+		"soon":                          {0, false},
+		"Mon, 01 Jan 2024 12:00:30 GMT": {30 * time.Second, true},
+		"Mon, 01 Jan 2024 11:00:00 GMT": {0, true},
+	} {
+		wait, ok := parseRetryAfter(value, now)
+		assert.Equal(t, want.wait, wait, value)
+		assert.Equal(t, want.ok, ok, value)
+	}
+}
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/trois-six/smc"
+)
+
+// minimumSMCVersion is the oldest SMC release supported by the provider: the
+// SMC client is generated from the API specification published by Stormshield
+// for the SMC 3.6 (smc-support-3.6-docs-api), older releases are not tested.
+var minimumSMCVersion = version.Must(version.NewVersion("3.6.0"))
+
+// errInvalidAPIKey is returned when the SMC rejects the API key.
+var errInvalidAPIKey = errors.New("the SMC rejected the API key")
+
+// errAuditLogsForbidden is returned when the API key is valid but is not
+// allowed to read the audit logs holding the SMC version.
+var errAuditLogsForbidden = errors.New("the API key is not allowed to read the SMC audit logs")
+
+// getSMCVersion checks that the SMC accepts the API key and returns its
+// version, read from the last audit logs as no endpoint exposes it to API
+// keys. The version is nil when no audit log holds it.
+func getSMCVersion(ctx context.Context, client *smc.ClientWithResponses) (*version.Version, error) {
+	respAPI, err := client.GetApiLogsAuditLastWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch respAPI.StatusCode() {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, errInvalidAPIKey
+	case http.StatusForbidden:
+		return nil, errAuditLogsForbidden
+	default:
+		return nil, fmt.Errorf("HTTP status code %s returned while reading the SMC audit logs", respAPI.Status())
+	}
+
+	if respAPI.JSON200 == nil || respAPI.JSON200.Lines == nil {
+		return nil, nil
+	}
+
+	for _, line := range *respAPI.JSON200.Lines {
+		if line.SmcVersion == nil || *line.SmcVersion == "" {
+			continue
+		}
+
+		smcVersion, err := version.NewVersion(*line.SmcVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMC version %q: %w", *line.SmcVersion, err)
+		}
+
+		return smcVersion, nil
+	}
+
+	return nil, nil
+}
+
+// smcVersionRequirement declares the oldest SMC version supporting an
+// attribute, or one of its values when Value is set. A resource declares one
+// only for the versions stated in the SMC release notes, and checks them with
+// checkSMCVersionRequirements in its ModifyPlan method.
+type smcVersionRequirement struct {
+	Path    path.Path
+	Value   string
+	Version *version.Version
+}
+
+// requiresSMCVersion returns the requirement of the attribute at p on the
+// SMC version v, e.g. "3.6".
+func requiresSMCVersion(p path.Path, v string) smcVersionRequirement {
+	return smcVersionRequirement{Path: p, Version: version.Must(version.NewVersion(v))}
+}
+
+// requiresSMCVersionValue returns the requirement of the given value of the
+// string, or collection of strings, attribute at p on the SMC version v.
+func requiresSMCVersionValue(p path.Path, value, v string) smcVersionRequirement {
+	return smcVersionRequirement{Path: p, Value: value, Version: version.Must(version.NewVersion(v))}
+}
+
+// holdsValue reports whether value is, or holds among its elements, the
+// string s.
+func holdsValue(value attr.Value, s string) bool {
+	var elements []attr.Value
+
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString() == s
+	case types.Set:
+		elements = v.Elements()
+	case types.List:
+		elements = v.Elements()
+	}
+
+	for _, element := range elements {
+		if str, ok := element.(types.String); ok && !str.IsNull() && !str.IsUnknown() && str.ValueString() == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSMCVersionRequirements returns an error for each attribute, or value,
+// set in the configuration which is not supported by the SMC version.
+// Nothing is checked when the version is unknown.
+func checkSMCVersionRequirements(ctx context.Context, smcVersion *version.Version, config tfsdk.Config, requirements []smcVersionRequirement) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if smcVersion == nil {
+		return diags
+	}
+
+	for _, requirement := range requirements {
+		if !smcVersion.LessThan(requirement.Version) {
+			continue
+		}
+
+		var value attr.Value
+
+		diags.Append(config.GetAttribute(ctx, requirement.Path, &value)...)
+
+		if value == nil || value.IsNull() {
+			continue
+		}
+
+		if requirement.Value == "" {
+			diags.AddAttributeError(
+				requirement.Path,
+				"Unsupported SMC Version",
+				fmt.Sprintf("attribute %s requires SMC >= %s, the SMC runs %s", requirement.Path, requirement.Version.Original(), smcVersion.Original()),
+			)
+
+			continue
+		}
+
+		if holdsValue(value, requirement.Value) {
+			diags.AddAttributeError(
+				requirement.Path,
+				"Unsupported SMC Version",
+				fmt.Sprintf("attribute %s value %q requires SMC >= %s, the SMC runs %s", requirement.Path, requirement.Value, requirement.Version.Original(), smcVersion.Original()),
+			)
+		}
+	}
+
+	return diags
+}
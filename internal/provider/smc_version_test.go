@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSMCVersionRequirements(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&AccountResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["email"] = tftypes.NewValue(tftypes.String, "user@example.com")
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+
+	requirements := []smcVersionRequirement{
+		requiresSMCVersion(path.Root("dn"), "3.6"),
+		requiresSMCVersion(path.Root("email"), "3.6"),
+	}
+
+	diags := checkSMCVersionRequirements(ctx, version.Must(version.NewVersion("3.5.2")), config, requirements)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Unsupported SMC Version", diags[0].Summary())
+	assert.Equal(t, "attribute email requires SMC >= 3.6, the SMC runs 3.5.2", diags[0].Detail())
+
+	diags = checkSMCVersionRequirements(ctx, version.Must(version.NewVersion("3.6.0")), config, requirements)
+	assert.Empty(t, diags)
+
+	// Nothing can be checked when the SMC version is unknown.
+	diags = checkSMCVersionRequirements(ctx, nil, config, requirements)
+	assert.Empty(t, diags)
+}
+
+func TestCheckSMCVersionRequirementsValue(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&AccountResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	config := func(permissions ...string) tfsdk.Config {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		elements := make([]tftypes.Value, len(permissions))
+		for idx, permission := range permissions {
+			elements[idx] = tftypes.NewValue(tftypes.String, permission)
+		}
+		values["permissions"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+
+		return tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		}
+	}
+
+	requirements := []smcVersionRequirement{
+		requiresSMCVersionValue(path.Root("permissions"), "ssh", "3.6"),
+	}
+
+	smcVersion := version.Must(version.NewVersion("3.5.2"))
+
+	diags := checkSMCVersionRequirements(ctx, smcVersion, config("smc", "ssh"), requirements)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Unsupported SMC Version", diags[0].Summary())
+	assert.Equal(t, `attribute permissions value "ssh" requires SMC >= 3.6, the SMC runs 3.5.2`, diags[0].Detail())
+
+	diags = checkSMCVersionRequirements(ctx, smcVersion, config("smc", "api"), requirements)
+	assert.Empty(t, diags)
+
+	diags = checkSMCVersionRequirements(ctx, version.Must(version.NewVersion("3.6.0")), config("console", "ssh"), requirements)
+	assert.Empty(t, diags)
+}
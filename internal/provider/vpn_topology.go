@@ -0,0 +1,548 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/trois-six/smc"
+)
+
+// VPNTopologyResourceModel describes the data model shared by the VPN
+// topology resources.
+type VPNTopologyResourceModel struct {
+	CertificateAuthorities types.Set              `tfsdk:"certificate_authorities"`
+	DPDMode                types.String           `tfsdk:"dpd_mode"`
+	Enabled                types.Bool             `tfsdk:"enabled"`
+	EncryptionProfile      types.String           `tfsdk:"encryption_profile"`
+	IKEDSCP                types.Int64            `tfsdk:"ike_dscp"`
+	IKEVersion             types.Int64            `tfsdk:"ike_version"`
+	Name                   types.String           `tfsdk:"name"`
+	Peers                  []VPNTopologyPeerModel `tfsdk:"peers"`
+	PMTUD                  types.Int64            `tfsdk:"pmtud"`
+	PSK                    types.String           `tfsdk:"psk"`
+	Tunnels                types.List             `tfsdk:"tunnels"`
+	UUID                   types.String           `tfsdk:"uuid"`
+}
+
+// VPNTopologyPeerModel describes a peer of a VPN topology.
+type VPNTopologyPeerModel struct {
+	Firewall            types.String `tfsdk:"firewall"`
+	ProtectedNetworks   types.Set    `tfsdk:"protected_networks"`
+	PublicIPAddressHost types.String `tfsdk:"public_ip_address_host"`
+	VPNLocalAddress     types.String `tfsdk:"vpn_local_address"`
+}
+
+// vpnTunnelAttrTypes describes a tunnel of a VPN topology.
+var vpnTunnelAttrTypes = map[string]attr.Type{
+	"left_gateway":  types.StringType,
+	"right_gateway": types.StringType,
+	"rule_name":     types.StringType,
+	"state":         types.StringType,
+}
+
+func getVPNTopologySchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"certificate_authorities": schema.SetAttribute{
+			MarkdownDescription: "UUIDs of the certificate authorities used to authenticate the peers, conflicts with `psk`",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"dpd_mode": schema.StringAttribute{
+			MarkdownDescription: "Dead Peer Detection mode (off, passive, low or high), defaults to `passive`",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("passive"),
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"off",
+					"passive",
+					"low",
+					"high",
+				),
+			},
+		},
+		"enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether the topology is enabled, defaults to `true`",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+		},
+		"encryption_profile": schema.StringAttribute{
+			MarkdownDescription: "UUID of the encryption profile",
+			Required:            true,
+		},
+		"ike_dscp": schema.Int64Attribute{
+			MarkdownDescription: "DSCP value, from 0 to 63, applied to the IKE traffic of the topology, defaults to the SMC's setting",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int64{
+				int64validator.Between(0, 63),
+			},
+		},
+		"ike_version": schema.Int64Attribute{
+			MarkdownDescription: "IKE version, defaults to `2`",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(2),
+			Validators: []validator.Int64{
+				int64validator.OneOf(1, 2),
+			},
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Topology name",
+			Required:            true,
+		},
+		"peers": schema.SetNestedAttribute{
+			MarkdownDescription: "Peers of the topology",
+			Required:            true,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(2),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"firewall": schema.StringAttribute{
+						MarkdownDescription: "UUID of the peer firewall",
+						Required:            true,
+					},
+					"protected_networks": schema.SetAttribute{
+						MarkdownDescription: "UUIDs of the network objects protected by the peer",
+						Required:            true,
+						ElementType:         types.StringType,
+					},
+					"public_ip_address_host": schema.StringAttribute{
+						MarkdownDescription: "UUID of the host object used as public address of the peer, or `any` if the peer is dynamic. Defaults to the firewall's setting.",
+						Optional:            true,
+					},
+					"vpn_local_address": schema.StringAttribute{
+						MarkdownDescription: "UUID of the object used as local address of the VPN. Defaults to the firewall's setting.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+		"pmtud": schema.Int64Attribute{
+			MarkdownDescription: "Path MTU Discovery mode: `0` disabled, `1` always add the DF flag, `2` keep the DF flag, defaults to the SMC's setting",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.Int64{
+				int64validator.OneOf(0, 1, 2),
+			},
+		},
+		"psk": schema.StringAttribute{
+			MarkdownDescription: "Pre-shared key used to authenticate the peers, conflicts with `certificate_authorities`",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"tunnels": schema.ListNestedAttribute{
+			MarkdownDescription: "Tunnels of the topology",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"left_gateway": schema.StringAttribute{
+						MarkdownDescription: "Gateway of the left side of the tunnel",
+						Computed:            true,
+					},
+					"right_gateway": schema.StringAttribute{
+						MarkdownDescription: "Gateway of the right side of the tunnel",
+						Computed:            true,
+					},
+					"rule_name": schema.StringAttribute{
+						MarkdownDescription: "Name of the tunnel in the VPN configuration",
+						Computed:            true,
+					},
+					"state": schema.StringAttribute{
+						MarkdownDescription: "State of the tunnel",
+						Computed:            true,
+					},
+				},
+			},
+		},
+		"uuid": schema.StringAttribute{
+			MarkdownDescription: "Topology uuid",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+func getVPNTopologyConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("certificate_authorities"),
+			path.MatchRoot("psk"),
+		),
+	}
+}
+
+// requestBody returns the API representation of the topology.
+func (m *VPNTopologyResourceModel) requestBody(ctx context.Context, shape smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidShape) (smc.DefinitionsTopologiesTopologyPropertiesWithoutUuid, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	topologyType := smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidTypePolicy
+
+	body := smc.DefinitionsTopologiesTopologyPropertiesWithoutUuid{
+		DpdMode:           smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidDpdMode(m.DPDMode.ValueString()),
+		Enabled:           m.Enabled.ValueBool(),
+		EncryptionProfile: m.EncryptionProfile.ValueString(),
+		IkeVersion:        smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidIkeVersion(m.IKEVersion.ValueInt64()),
+		Name:              m.Name.ValueString(),
+		Peers:             make([]smc.DefinitionsTopologiesTopologyPeerPropertiesWithoutReadOnly, len(m.Peers)),
+		Psk:               m.PSK.ValueStringPointer(),
+		Shape:             &shape,
+		Type:              &topologyType,
+	}
+
+	if !m.IKEDSCP.IsNull() && !m.IKEDSCP.IsUnknown() {
+		ikeDSCP := float32(m.IKEDSCP.ValueInt64())
+		body.IkeDscp = &ikeDSCP
+	}
+
+	if !m.PMTUD.IsNull() && !m.PMTUD.IsUnknown() {
+		pmtud := smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidPmtud(m.PMTUD.ValueInt64())
+		body.Pmtud = &pmtud
+	}
+
+	if !m.CertificateAuthorities.IsNull() {
+		var authorities []string
+		diags.Append(m.CertificateAuthorities.ElementsAs(ctx, &authorities, false)...)
+		body.Authorities = &authorities
+	}
+
+	for idx, peer := range m.Peers {
+		var endpoints []string
+		diags.Append(peer.ProtectedNetworks.ElementsAs(ctx, &endpoints, false)...)
+
+		body.Peers[idx] = smc.DefinitionsTopologiesTopologyPeerPropertiesWithoutReadOnly{
+			Endpoints:           &endpoints,
+			PublicIpAddressHost: peer.PublicIPAddressHost.ValueString(),
+			Uuid:                peer.Firewall.ValueString(),
+			VpnLocalAddress:     peer.VPNLocalAddress.ValueString(),
+		}
+	}
+
+	return body, diags
+}
+
+// withUUID returns the API representation of an existing topology.
+func (m *VPNTopologyResourceModel) withUUID(body smc.DefinitionsTopologiesTopologyPropertiesWithoutUuid) smc.DefinitionsTopologiesTopologyPropertiesWithUuid {
+	peers := make([]smc.DefinitionsTopologiesTopologyPeerProperties, len(body.Peers))
+	for idx, peer := range body.Peers {
+		peers[idx] = smc.DefinitionsTopologiesTopologyPeerProperties{
+			Endpoints:           peer.Endpoints,
+			PublicIpAddressHost: peer.PublicIpAddressHost,
+			Uuid:                peer.Uuid,
+			VpnLocalAddress:     peer.VpnLocalAddress,
+		}
+	}
+
+	return smc.DefinitionsTopologiesTopologyPropertiesWithUuid{
+		Authorities:       body.Authorities,
+		Center:            body.Center,
+		DpdMode:           smc.DefinitionsTopologiesTopologyPropertiesWithUuidDpdMode(body.DpdMode),
+		Enabled:           body.Enabled,
+		EncryptionProfile: body.EncryptionProfile,
+		IkeDscp:           body.IkeDscp,
+		IkeVersion:        smc.DefinitionsTopologiesTopologyPropertiesWithUuidIkeVersion(body.IkeVersion),
+		Name:              body.Name,
+		Peers:             peers,
+		Pmtud:             (*smc.DefinitionsTopologiesTopologyPropertiesWithUuidPmtud)(body.Pmtud),
+		Psk:               body.Psk,
+		ResponderOnly:     body.ResponderOnly,
+		Shape:             (*smc.DefinitionsTopologiesTopologyPropertiesWithUuidShape)(body.Shape),
+		Type:              (*smc.DefinitionsTopologiesTopologyPropertiesWithUuidType)(body.Type),
+		Uuid:              m.UUID.ValueString(),
+	}
+}
+
+// stringOrNull returns a null string for empty values, the API returning
+// empty strings for unset fields.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+func readVPNTopologyResourceModel(ctx context.Context, data *VPNTopologyResourceModel, item *smc.DefinitionsTopologiesTopologyPropertiesWithUuid, tunnels []smc.DefinitionsTunnelsTunnelProperties) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if item.Authorities != nil && len(*item.Authorities) > 0 {
+		setValue, d := types.SetValueFrom(ctx, types.StringType, *item.Authorities)
+		diags.Append(d...)
+		data.CertificateAuthorities = setValue
+	} else {
+		data.CertificateAuthorities = types.SetNull(types.StringType)
+	}
+
+	data.DPDMode = types.StringValue(string(item.DpdMode))
+	data.Enabled = types.BoolValue(item.Enabled)
+	data.EncryptionProfile = types.StringValue(item.EncryptionProfile)
+	data.IKEVersion = types.Int64Value(int64(item.IkeVersion))
+	data.Name = types.StringValue(item.Name)
+
+	data.IKEDSCP = types.Int64Null()
+	if item.IkeDscp != nil {
+		data.IKEDSCP = types.Int64Value(int64(*item.IkeDscp))
+	}
+
+	data.PMTUD = types.Int64Null()
+	if item.Pmtud != nil {
+		data.PMTUD = types.Int64Value(int64(*item.Pmtud))
+	}
+
+	data.Peers = make([]VPNTopologyPeerModel, len(item.Peers))
+	for idx, peer := range item.Peers {
+		endpoints := []string{}
+		if peer.Endpoints != nil {
+			endpoints = *peer.Endpoints
+		}
+
+		setValue, d := types.SetValueFrom(ctx, types.StringType, endpoints)
+		diags.Append(d...)
+
+		data.Peers[idx] = VPNTopologyPeerModel{
+			Firewall:            types.StringValue(peer.Uuid),
+			ProtectedNetworks:   setValue,
+			PublicIPAddressHost: stringOrNull(peer.PublicIpAddressHost),
+			VPNLocalAddress:     stringOrNull(peer.VpnLocalAddress),
+		}
+	}
+
+	// The API does not always return the pre-shared key, the configured one is
+	// kept in that case.
+	if item.Psk != nil {
+		data.PSK = types.StringValue(*item.Psk)
+	}
+
+	tunnelAttrs := []attr.Value{}
+	for _, tunnel := range tunnels {
+		if tunnel.Uuid != item.Uuid {
+			continue
+		}
+
+		var state *string
+		if tunnel.Status.State != nil {
+			value := string(*tunnel.Status.State)
+			state = &value
+		}
+
+		tunnelValue, d := types.ObjectValue(vpnTunnelAttrTypes, map[string]attr.Value{
+			"left_gateway":  types.StringPointerValue(tunnel.Left.Gateway),
+			"right_gateway": types.StringPointerValue(tunnel.Right.Gateway),
+			"rule_name":     types.StringValue(tunnel.Rulename),
+			"state":         types.StringPointerValue(state),
+		})
+		diags.Append(d...)
+		tunnelAttrs = append(tunnelAttrs, tunnelValue)
+	}
+
+	listValue, d := types.ListValue(types.ObjectType{AttrTypes: vpnTunnelAttrTypes}, tunnelAttrs)
+	diags.Append(d...)
+	data.Tunnels = listValue
+
+	data.UUID = types.StringValue(item.Uuid)
+
+	return diags
+}
+
+// getVPNTunnels returns all the VPN tunnels known by the SMC.
+func getVPNTunnels(ctx context.Context, client *smc.ClientWithResponses) ([]smc.DefinitionsTunnelsTunnelProperties, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	respAPI, err := client.GetApiVpnTunnelsWithResponse(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Reading the SMC VPN Tunnels",
+			"Could not read the SMC VPN tunnels: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"HTTP Error Reading the SMC VPN Tunnels",
+			"HTTP status code "+respAPI.Status()+" returned while reading the SMC VPN tunnels",
+		)
+		return nil, diags
+	}
+
+	if respAPI.JSON200 == nil || respAPI.JSON200.Result == nil {
+		return nil, diags
+	}
+
+	return *respAPI.JSON200.Result, diags
+}
+
+func createVPNTopology(ctx context.Context, client *smc.ClientWithResponses, body smc.DefinitionsTopologiesTopologyPropertiesWithoutUuid) (*smc.DefinitionsTopologiesTopologyPropertiesWithUuid, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	respAPI, err := client.PostApiVpnTopologiesWithResponse(ctx, body)
+	if err != nil {
+		diags.AddError(
+			"Error Creating the SMC VPN Topology",
+			"Could not create the SMC VPN topology "+body.Name+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"HTTP Error Creating the SMC VPN Topology",
+			"HTTP status code "+respAPI.Status()+" returned while creating the SMC VPN topology",
+		)
+		return nil, diags
+	}
+
+	if respAPI.JSON200 == nil || respAPI.JSON200.Result == nil {
+		diags.AddError(
+			"No results Reading response after creating the SMC VPN Topology",
+			"No results returned after creating the SMC VPN Topology",
+		)
+		return nil, diags
+	}
+
+	return respAPI.JSON200.Result, diags
+}
+
+// readVPNTopology returns the topology with the given UUID, or nil without
+// error when it does not exist anymore.
+func readVPNTopology(ctx context.Context, client *smc.ClientWithResponses, uuid string) (*smc.DefinitionsTopologiesTopologyPropertiesWithUuid, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	respAPI, err := client.GetApiVpnTopologiesUuidWithResponse(ctx, uuid)
+	if err != nil {
+		diags.AddError(
+			"Error Reading the SMC VPN Topology",
+			"Could not read the SMC VPN topology with UUID "+uuid+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if respAPI.StatusCode() == http.StatusNotFound {
+		return nil, diags
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"HTTP Error Reading the SMC VPN Topology",
+			"HTTP status code "+respAPI.Status()+" returned while reading the SMC VPN topology",
+		)
+		return nil, diags
+	}
+
+	if respAPI.JSON200 == nil || respAPI.JSON200.Result == nil {
+		diags.AddError(
+			"No result Reading the SMC VPN Topology",
+			"No result returned after reading the SMC VPN Topology",
+		)
+		return nil, diags
+	}
+
+	return respAPI.JSON200.Result, diags
+}
+
+func updateVPNTopology(ctx context.Context, client *smc.ClientWithResponses, body smc.DefinitionsTopologiesTopologyPropertiesWithUuid) (*smc.DefinitionsTopologiesTopologyPropertiesWithUuid, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	respAPI, err := client.PutApiVpnTopologiesUuidWithResponse(ctx, body.Uuid, body)
+	if err != nil {
+		diags.AddError(
+			"Error Updating the SMC VPN Topology",
+			"Could not update the SMC VPN topology UUID "+body.Uuid+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"HTTP Error Updating the SMC VPN Topology",
+			"HTTP status code "+respAPI.Status()+" returned while updating the SMC VPN topology",
+		)
+		return nil, diags
+	}
+
+	if respAPI.JSON200 == nil || respAPI.JSON200.Result == nil {
+		diags.AddError(
+			"No results Reading response after updating the SMC VPN Topology",
+			"No results returned after updating the SMC VPN Topology",
+		)
+		return nil, diags
+	}
+
+	return respAPI.JSON200.Result, diags
+}
+
+func deleteVPNTopology(ctx context.Context, client *smc.ClientWithResponses, uuid string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	respAPI, err := client.DeleteApiVpnTopologiesUuidWithResponse(ctx, uuid)
+	if err != nil {
+		diags.AddError(
+			"Error Deleting the SMC VPN Topology",
+			"Could not delete the SMC VPN topology UUID "+uuid+": "+err.Error(),
+		)
+		return diags
+	}
+
+	// The topology was already deleted outside of Terraform.
+	if respAPI.StatusCode() == http.StatusNotFound {
+		tflog.Trace(ctx, "VPN topology already deleted", map[string]interface{}{"uuid": uuid})
+		return diags
+	}
+
+	if respAPI.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"HTTP Error Deleting the SMC VPN Topology",
+			"HTTP status code "+respAPI.Status()+" returned while deleting the SMC VPN topology",
+		)
+	}
+
+	return diags
+}
+
+// checkVPNTopologyShape ensures the topology read from the API has the shape
+// managed by the resource, e.g. when importing it.
+func checkVPNTopologyShape(item *smc.DefinitionsTopologiesTopologyPropertiesWithUuid, shape smc.DefinitionsTopologiesTopologyPropertiesWithUuidShape) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if item.Shape != nil && *item.Shape != shape {
+		diags.AddError(
+			"Unexpected SMC VPN Topology Shape",
+			"The SMC VPN topology with UUID "+item.Uuid+" is a "+string(*item.Shape)+" topology, not a "+string(shape)+" topology",
+		)
+	}
+
+	return diags
+}
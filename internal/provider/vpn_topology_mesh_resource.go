@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VPNTopologyMeshResource{}
+var _ resource.ResourceWithConfigure = &VPNTopologyMeshResource{}
+var _ resource.ResourceWithConfigValidators = &VPNTopologyMeshResource{}
+var _ resource.ResourceWithImportState = &VPNTopologyMeshResource{}
+
+func NewVPNTopologyMeshResource() resource.Resource {
+	return &VPNTopologyMeshResource{}
+}
+
+// VPNTopologyMeshResource defines the resource implementation.
+type VPNTopologyMeshResource struct {
+	providerData *SMCProviderData
+}
+
+func (r *VPNTopologyMeshResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpn_topology_mesh"
+}
+
+func (r *VPNTopologyMeshResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manage a policy-based IPsec VPN topology where every peer is connected to all the others.",
+
+		Attributes: getVPNTopologySchemaAttributes(),
+	}
+}
+
+func (r *VPNTopologyMeshResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return getVPNTopologyConfigValidators()
+}
+
+func (r *VPNTopologyMeshResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *VPNTopologyMeshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VPNTopologyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.requestBody(ctx, smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidShapeMesh)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := createVPNTopology(ctx, r.providerData.Client, body)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data, item, tunnels)...)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Created a mesh VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyMeshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VPNTopologyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := readVPNTopology(ctx, r.providerData.Client, data.UUID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The topology was deleted outside of Terraform, it is planned for
+	// creation again.
+	if item == nil {
+		tflog.Warn(ctx, "VPN topology not found, removing it from the state", map[string]interface{}{"uuid": data.UUID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkVPNTopologyShape(item, smc.DefinitionsTopologiesTopologyPropertiesWithUuidShapeMesh)...)
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data, item, tunnels)...)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Read a mesh VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyMeshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VPNTopologyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.requestBody(ctx, smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidShapeMesh)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := updateVPNTopology(ctx, r.providerData.Client, data.withUUID(body))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data, item, tunnels)...)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Updated a mesh VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyMeshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VPNTopologyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deleteVPNTopology(ctx, r.providerData.Client, data.UUID.ValueString())...)
+}
+
+func (r *VPNTopologyMeshResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trois-six/smc"
+)
+
+// testAccVPNTopologyServer mocks the SMC VPN topologies API, storing the last
+// topology sent to it. The topology is not found once deleted is set.
+func testAccVPNTopologyServer(t *testing.T, deleted *atomic.Bool) *httptest.Server {
+	topology := map[string]any{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var result any
+		switch {
+		case deleted.Load() && r.URL.Path == "/api/vpn/topologies/topology-uuid":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": "ENOTFOUND", "message": "topology not found"}]}`))
+			return
+		case r.URL.Path == "/api/vpn/tunnels":
+			result = []map[string]any{
+				{
+					"uuid":     "topology-uuid",
+					"name":     topology["name"],
+					"rulename": "tunnel-1",
+					"shape":    topology["shape"],
+					"type":     "policy",
+					"left":     map[string]any{"gateway": "firewall-paris-uuid"},
+					"right":    map[string]any{"gateway": "firewall-lyon-uuid"},
+					"status":   map[string]any{"state": "up"},
+				},
+			}
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			topology = map[string]any{}
+			if err := json.NewDecoder(r.Body).Decode(&topology); err != nil {
+				t.Errorf("error reading body: %s", err)
+			}
+			topology["uuid"] = "topology-uuid"
+			fallthrough
+		default:
+			result = topology
+		}
+
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(map[string]any{"result": result, "success": true})
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
+	}))
+}
+
+func TestAccVPNTopologyMeshResource(t *testing.T) {
+	var deleted atomic.Bool
+
+	testServer := testAccVPNTopologyServer(t, &deleted)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccVPNTopologyMeshResourceConfig("low"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "dpd_mode", "low"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "enabled", "true"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "encryption_profile", "profile-uuid"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "ike_dscp", "46"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "ike_version", "2"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "name", "branches"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "peers.#", "2"),
+					resource.TestCheckNoResourceAttr("smc_vpn_topology_mesh.branches", "pmtud"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "psk", "secret"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "tunnels.#", "1"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "tunnels.0.rule_name", "tunnel-1"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "tunnels.0.state", "up"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "uuid", "topology-uuid"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "smc_vpn_topology_mesh.branches",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccVPNTopologyMeshResourceConfig("high"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_vpn_topology_mesh.branches", "dpd_mode", "high"),
+				),
+			},
+			// The topology is deleted in the SMC console, the plan creates it
+			// again instead of failing.
+			{
+				PreConfig:          func() { deleted.Store(true) },
+				Config:             fmt.Sprintf(providerConfig, testServer.URL) + testAccVPNTopologyMeshResourceConfig("high"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The destroy of the topology already deleted succeeds.
+		},
+	})
+}
+
+func testAccVPNTopologyMeshResourceConfig(dpdMode string) string {
+	return fmt.Sprintf(`
+resource "smc_vpn_topology_mesh" "branches" {
+  name               = "branches"
+  encryption_profile = "profile-uuid"
+  dpd_mode           = %[1]q
+  ike_dscp           = 46
+  psk                = "secret"
+
+  peers = [
+    {
+      firewall           = "firewall-paris-uuid"
+      protected_networks = ["network-paris-uuid"]
+    },
+    {
+      firewall           = "firewall-lyon-uuid"
+      protected_networks = ["network-lyon-uuid"]
+    },
+  ]
+}
+`, dpdMode)
+}
+
+func TestReadVPNTopologyNotFound(t *testing.T) {
+	var deleted atomic.Bool
+	deleted.Store(true)
+
+	testServer := testAccVPNTopologyServer(t, &deleted)
+	defer testServer.Close()
+
+	client, err := smc.NewClientWithResponses(testServer.URL)
+	require.NoError(t, err)
+
+	// The topology deleted outside of Terraform is not an error.
+	item, diags := readVPNTopology(context.Background(), client, "topology-uuid")
+	assert.False(t, diags.HasError())
+	assert.Nil(t, item)
+
+	assert.False(t, deleteVPNTopology(context.Background(), client, "topology-uuid").HasError())
+}
@@ -0,0 +1,288 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/trois-six/smc"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VPNTopologyStarResource{}
+var _ resource.ResourceWithConfigure = &VPNTopologyStarResource{}
+var _ resource.ResourceWithConfigValidators = &VPNTopologyStarResource{}
+var _ resource.ResourceWithImportState = &VPNTopologyStarResource{}
+
+func NewVPNTopologyStarResource() resource.Resource {
+	return &VPNTopologyStarResource{}
+}
+
+// VPNTopologyStarResource defines the resource implementation.
+type VPNTopologyStarResource struct {
+	providerData *SMCProviderData
+}
+
+// VPNTopologyStarResourceModel describes the resource data model.
+type VPNTopologyStarResourceModel struct {
+	VPNTopologyResourceModel
+
+	Center        types.String `tfsdk:"center"`
+	ResponderOnly types.Bool   `tfsdk:"responder_only"`
+}
+
+func getVPNTopologyStarSchemaAttributes() map[string]schema.Attribute {
+	attributes := getVPNTopologySchemaAttributes()
+
+	attributes["center"] = schema.StringAttribute{
+		MarkdownDescription: "UUID of the peer firewall at the center of the topology, it must be the `firewall` of one of the `peers`",
+		Required:            true,
+	}
+	attributes["responder_only"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the center only responds to the VPN tunnels initiated by the other peers, defaults to `false`",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+
+	return attributes
+}
+
+// readVPNTopologyStarResourceModel reads the attributes specific to the star
+// topologies.
+func readVPNTopologyStarResourceModel(data *VPNTopologyStarResourceModel, item *smc.DefinitionsTopologiesTopologyPropertiesWithUuid) {
+	data.Center = types.StringPointerValue(item.Center)
+	data.ResponderOnly = types.BoolValue(item.ResponderOnly != nil && *item.ResponderOnly)
+}
+
+// vpnTopologyStarCenterValidator ensures the center of a star topology is the
+// firewall of one of its peers.
+type vpnTopologyStarCenterValidator struct{}
+
+var _ resource.ConfigValidator = vpnTopologyStarCenterValidator{}
+
+func (v vpnTopologyStarCenterValidator) Description(ctx context.Context) string {
+	return "center must be the firewall of one of the peers"
+}
+
+func (v vpnTopologyStarCenterValidator) MarkdownDescription(ctx context.Context) string {
+	return "`center` must be the `firewall` of one of the `peers`"
+}
+
+func (v vpnTopologyStarCenterValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var center types.String
+	var peers types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("center"), &center)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("peers"), &peers)...)
+
+	// Nothing can be checked until the values are known.
+	if resp.Diagnostics.HasError() || center.IsNull() || center.IsUnknown() || peers.IsNull() || peers.IsUnknown() {
+		return
+	}
+
+	for _, peer := range peers.Elements() {
+		peerObject, ok := peer.(types.Object)
+		if !ok || peerObject.IsUnknown() {
+			return
+		}
+
+		firewall, ok := peerObject.Attributes()["firewall"].(types.String)
+		if !ok || firewall.IsUnknown() {
+			return
+		}
+
+		if firewall.Equal(center) {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("center"),
+		"Invalid Star VPN Topology Center",
+		"The center "+center.String()+" is not the firewall of any peer of the topology",
+	)
+}
+
+func (r *VPNTopologyStarResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpn_topology_star"
+}
+
+func (r *VPNTopologyStarResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manage a policy-based IPsec VPN topology where every peer is connected to a center peer.",
+
+		Attributes: getVPNTopologyStarSchemaAttributes(),
+	}
+}
+
+func (r *VPNTopologyStarResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append(getVPNTopologyConfigValidators(), vpnTopologyStarCenterValidator{})
+}
+
+func (r *VPNTopologyStarResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SMCProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.SMCProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *VPNTopologyStarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VPNTopologyStarResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.requestBody(ctx, smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidShapeStar)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body.Center = data.Center.ValueStringPointer()
+	body.ResponderOnly = data.ResponderOnly.ValueBoolPointer()
+
+	item, diags := createVPNTopology(ctx, r.providerData.Client, body)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data.VPNTopologyResourceModel, item, tunnels)...)
+	readVPNTopologyStarResourceModel(&data, item)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Created a star VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyStarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VPNTopologyStarResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := readVPNTopology(ctx, r.providerData.Client, data.UUID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The topology was deleted outside of Terraform, it is planned for
+	// creation again.
+	if item == nil {
+		tflog.Warn(ctx, "VPN topology not found, removing it from the state", map[string]interface{}{"uuid": data.UUID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(checkVPNTopologyShape(item, smc.DefinitionsTopologiesTopologyPropertiesWithUuidShapeStar)...)
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data.VPNTopologyResourceModel, item, tunnels)...)
+	readVPNTopologyStarResourceModel(&data, item)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Read a star VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyStarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VPNTopologyStarResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.requestBody(ctx, smc.DefinitionsTopologiesTopologyPropertiesWithoutUuidShapeStar)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body.Center = data.Center.ValueStringPointer()
+	body.ResponderOnly = data.ResponderOnly.ValueBoolPointer()
+
+	item, diags := updateVPNTopology(ctx, r.providerData.Client, data.withUUID(body))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tunnels, diags := getVPNTunnels(ctx, r.providerData.Client)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(readVPNTopologyResourceModel(ctx, &data.VPNTopologyResourceModel, item, tunnels)...)
+	readVPNTopologyStarResourceModel(&data, item)
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "Updated a star VPN topology", map[string]interface{}{"uuid": data.UUID})
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VPNTopologyStarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VPNTopologyStarResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deleteVPNTopology(ctx, r.providerData.Client, data.UUID.ValueString())...)
+}
+
+func (r *VPNTopologyStarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
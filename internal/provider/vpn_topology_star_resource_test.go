@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccVPNTopologyStarResource(t *testing.T) {
+	var deleted atomic.Bool
+
+	testServer := testAccVPNTopologyServer(t, &deleted)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccVPNTopologyStarResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "center", "firewall-paris-uuid"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "certificate_authorities.#", "1"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "certificate_authorities.0", "ca-uuid"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "name", "headquarters"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "peers.#", "2"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "responder_only", "false"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "tunnels.#", "1"),
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "uuid", "topology-uuid"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "smc_vpn_topology_star.headquarters",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: fmt.Sprintf(providerConfig, testServer.URL) + testAccVPNTopologyStarResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("smc_vpn_topology_star.headquarters", "responder_only", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccVPNTopologyStarResourceCenterNotPeer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(providerConfig, "https://smc.example.com") +
+					strings.Replace(testAccVPNTopologyStarResourceConfig(false), `center                  = "firewall-paris-uuid"`, `center                  = "firewall-nice-uuid"`, 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is not the firewall of any peer of the topology`),
+			},
+		},
+	})
+}
+
+func testAccVPNTopologyStarResourceConfig(responderOnly bool) string {
+	return fmt.Sprintf(`
+resource "smc_vpn_topology_star" "headquarters" {
+  name                    = "headquarters"
+  encryption_profile      = "profile-uuid"
+  certificate_authorities = ["ca-uuid"]
+  center                  = "firewall-paris-uuid"
+  responder_only          = %[1]t
+
+  peers = [
+    {
+      firewall           = "firewall-paris-uuid"
+      protected_networks = ["network-paris-uuid"]
+    },
+    {
+      firewall           = "firewall-lyon-uuid"
+      protected_networks = ["network-lyon-uuid"]
+    },
+  ]
+}
+`, responderOnly)
+}
+
+func TestVPNTopologyStarCenterValidator(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&VPNTopologyStarResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	peerType := objectType.AttributeTypes["peers"].(tftypes.Set).ElementType.(tftypes.Object)
+
+	config := func(center any, firewalls ...any) tfsdk.Config {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		peers := make([]tftypes.Value, len(firewalls))
+		for idx, firewall := range firewalls {
+			peerValues := make(map[string]tftypes.Value, len(peerType.AttributeTypes))
+			for name, attributeType := range peerType.AttributeTypes {
+				peerValues[name] = tftypes.NewValue(attributeType, nil)
+			}
+			peerValues["firewall"] = tftypes.NewValue(tftypes.String, firewall)
+			peers[idx] = tftypes.NewValue(peerType, peerValues)
+		}
+
+		values["center"] = tftypes.NewValue(tftypes.String, center)
+		values["peers"] = tftypes.NewValue(objectType.AttributeTypes["peers"], peers)
+
+		return tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		config    tfsdk.Config
+		expectErr bool
+	}{
+		{"center is a peer", config("firewall-paris-uuid", "firewall-paris-uuid", "firewall-lyon-uuid"), false},
+		{"center is not a peer", config("firewall-nice-uuid", "firewall-paris-uuid", "firewall-lyon-uuid"), true},
+		{"unknown center", config(tftypes.UnknownValue, "firewall-paris-uuid", "firewall-lyon-uuid"), false},
+		{"unknown peer firewall", config("firewall-nice-uuid", "firewall-paris-uuid", tftypes.UnknownValue), false},
+	} {
+		var resp fwresource.ValidateConfigResponse
+		vpnTopologyStarCenterValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: tc.config}, &resp)
+		assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError(), tc.name)
+	}
+}